@@ -0,0 +1,109 @@
+package human
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var _ error = (*CycleError)(nil)
+
+// CycleError is an error that indicates that a value references itself,
+// either directly or through one of its children
+type CycleError struct {
+	path string
+	typ  reflect.Type
+}
+
+// Error returns the error string and causes CycleError to implement the error interface.
+// The path and type are not part of the error string, as the EncodeError wrapping the
+// CycleError holds them already.
+func (ce *CycleError) Error() string {
+	return "cycle detected"
+}
+
+// Path returns the path of the field that closes the cycle
+func (ce *CycleError) Path() string {
+	return ce.path
+}
+
+// Type returns the type of the value that closes the cycle
+func (ce *CycleError) Type() reflect.Type {
+	return ce.typ
+}
+
+func newErrorCycle(path string, typ reflect.Type) error {
+	return &CycleError{
+		path: path,
+		typ:  typ,
+	}
+}
+
+// IsCycleError checks if the given error is a CycleError error
+// and returns the CycleError error along with a boolean that defines
+// if it is indeed a cycle error.
+// The error may also be the cause of an EncodeError or be aggregated by EncodeErrors,
+// in which case the first CycleError is returned.
+// The returned *CycleError may be nil, if the flag is false
+func IsCycleError(err error) (ce *CycleError, ok bool) {
+	ok = walkError(err, func(err error) bool {
+		ce, ok = err.(*CycleError)
+		return ok
+	})
+	return
+}
+
+// visitKey identifies a pointer, map or slice header that is currently being encoded
+type visitKey struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+// newVisitKey returns the visitKey for a given value and a flag which defines if the
+// value's kind is tracked at all
+func newVisitKey(v reflect.Value) (key visitKey, ok bool) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map:
+		if v.IsNil() {
+			return
+		}
+		return visitKey{ptr: v.Pointer(), typ: v.Type()}, true
+	case reflect.Slice:
+		if v.IsNil() || v.Len() == 0 {
+			return
+		}
+		return visitKey{ptr: v.Pointer(), typ: v.Type(), len: v.Len()}, true
+	}
+	return
+}
+
+// enter marks the given value as being encoded. It returns false if the value
+// is already being encoded, which means that a cycle has been detected.
+func (e *encodeState) enter(v reflect.Value) bool {
+	key, ok := newVisitKey(v)
+	if !ok {
+		return true
+	}
+	if _, visited := e.visited[key]; visited {
+		return false
+	}
+	e.visited[key] = struct{}{}
+	return true
+}
+
+// leave removes the mark set by enter
+func (e *encodeState) leave(v reflect.Value) {
+	if key, ok := newVisitKey(v); ok {
+		delete(e.visited, key)
+	}
+}
+
+// encodeCycle handles a detected cycle according to the configured CycleHandling
+func (e *encodeState) encodeCycle(v reflect.Value) {
+	path := formatPath(e.path)
+	if e.cycleHandling == CycleHandlingError {
+		e.handleError(v, newErrorCycle(path, v.Type()))
+		return
+	}
+	fmt.Fprintf(e.stream, " <cycle: %s>\n", path)
+}
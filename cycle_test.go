@@ -0,0 +1,93 @@
+package human
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type cycleNode struct {
+	Name     string
+	Parent   *cycleNode   `human:",omitempty"`
+	Children []*cycleNode `human:",omitempty"`
+}
+
+func TestIsCycleError(t *testing.T) {
+	err := newErrorCycle("Parent", reflect.TypeOf(&cycleNode{}))
+	cycleErr, isCycle := IsCycleError(err)
+	require.NotNil(t, cycleErr)
+	require.True(t, isCycle)
+	require.EqualValues(t, "Parent", cycleErr.Path())
+	require.EqualValues(t, reflect.TypeOf(&cycleNode{}), cycleErr.Type())
+}
+
+func TestCycleErrorError(t *testing.T) {
+	err := newErrorCycle("Parent", reflect.TypeOf(&cycleNode{}))
+	require.EqualValues(t, "cycle detected", err.Error())
+}
+
+func TestEncoder_Encode_Cycle(t *testing.T) {
+	root := &cycleNode{Name: "root"}
+	child := &cycleNode{Name: "child", Parent: root}
+	root.Children = []*cycleNode{child}
+
+	t.Run("Marker", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer)
+		require.NoError(t, err)
+
+		require.NoError(t, enc.Encode(root))
+		require.EqualValues(t, "\nName: root\nChildren:\n  * Name: child\n    Parent: <cycle: Children[0].Parent>\n", outputBuffer.String())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer, OptionCycleHandling(CycleHandlingError))
+		require.NoError(t, err)
+
+		err = enc.Encode(root)
+		require.Error(t, err)
+		encodeErr := findEncodeError(t, err, "Children[0].Parent")
+		require.EqualValues(t, "Children[0].Parent (*human.cycleNode): cycle detected", encodeErr.Error())
+		cycleErr, isCycle := IsCycleError(encodeErr.Err)
+		require.True(t, isCycle)
+		require.EqualValues(t, "Children[0].Parent", cycleErr.Path())
+		require.EqualValues(t, "", outputBuffer.String())
+	})
+
+	t.Run("SharedPointer", func(t *testing.T) {
+		// The same pointer may occur multiple times, as long as it does not reference itself
+		shared := &cycleNode{Name: "shared"}
+		s := []*cycleNode{shared, shared}
+
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer, OptionCycleHandling(CycleHandlingError))
+		require.NoError(t, err)
+
+		require.NoError(t, enc.Encode(s))
+		require.EqualValues(t, "\n* Name: shared\n* Name: shared\n", outputBuffer.String())
+	})
+
+	t.Run("Interface", func(t *testing.T) {
+		// Cycles passing through interfaces, like a map containing itself, are detected as well
+		m := map[string]interface{}{"name": "self"}
+		m["self"] = m
+
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer)
+		require.NoError(t, err)
+
+		require.NoError(t, enc.Encode(m))
+		require.EqualValues(t, "\n* name: self\n* self: <cycle: [self]>\n", outputBuffer.String())
+
+		enc, err = NewEncoder(bytes.NewBufferString(""), OptionCycleHandling(CycleHandlingError))
+		require.NoError(t, err)
+
+		err = enc.Encode(struct{ Values []interface{} }{Values: []interface{}{m}})
+		require.Error(t, err)
+		_, isCycle := IsCycleError(findEncodeError(t, err, "Values[0][self]").Err)
+		require.True(t, isCycle)
+	})
+}
@@ -0,0 +1,149 @@
+package human
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var _ error = (*EncodeError)(nil)
+
+// EncodeError is an error that indicates that a value could not be encoded
+type EncodeError struct {
+	// Path is the path to the value, like "Spec.Disks[2].Size". It is empty for the top-level value.
+	Path string
+	// Type is the Go type of the value, which may be nil if it is not known
+	Type reflect.Type
+	// Err is the cause of the error
+	Err error
+}
+
+// Error returns the error string and causes EncodeError to implement the error interface
+func (ee *EncodeError) Error() string {
+	var location []string
+	if ee.Path != "" {
+		location = append(location, ee.Path)
+	}
+	if ee.Type != nil {
+		location = append(location, "("+ee.Type.String()+")")
+	}
+	if len(location) == 0 {
+		return ee.Err.Error()
+	}
+	return fmt.Sprintf("%s: %s", strings.Join(location, " "), ee.Err)
+}
+
+// Unwrap returns the cause of the error, which allows for inspecting it using errors.Is and errors.As
+func (ee *EncodeError) Unwrap() error {
+	return ee.Err
+}
+
+func newEncodeError(path string, typ reflect.Type, err error) *EncodeError {
+	return &EncodeError{
+		Path: path,
+		Type: typ,
+		Err:  err,
+	}
+}
+
+// IsEncodeError checks if the given error is an EncodeError error
+// and returns the EncodeError error along with a boolean that defines
+// if it is indeed an encode error.
+// The error may also be aggregated by EncodeErrors, in which case the first EncodeError is returned.
+// The returned *EncodeError may be nil, if the flag is false
+func IsEncodeError(err error) (ee *EncodeError, ok bool) {
+	ok = walkError(err, func(err error) bool {
+		ee, ok = err.(*EncodeError)
+		return ok
+	})
+	return
+}
+
+// walkError calls match for the given error and the errors it wraps, until match returns true.
+// The wrapped errors are the errors aggregated by EncodeErrors and the cause of an EncodeError,
+// which allows for inspecting the errors returned by Encoder.Encode without errors.As, which
+// requires Go 1.13, or its support for aggregated errors, which requires Go 1.20.
+func walkError(err error, match func(error) bool) bool {
+	if err == nil {
+		return false
+	} else if match(err) {
+		return true
+	}
+
+	switch wrapper := err.(type) {
+	case EncodeErrors:
+		for _, ee := range wrapper {
+			if walkError(ee, match) {
+				return true
+			}
+		}
+	case *EncodeError:
+		if wrapper != nil {
+			return walkError(wrapper.Err, match)
+		}
+	}
+	return false
+}
+
+var _ error = EncodeErrors(nil)
+
+// EncodeErrors is returned by Encoder.Encode and aggregates the errors of all values that could
+// not be encoded, in order of occurrence
+type EncodeErrors []*EncodeError
+
+// Error returns the error string and causes EncodeErrors to implement the error interface
+func (errs EncodeErrors) Error() string {
+	if len(errs) == 1 {
+		return fmt.Sprintf("1 error occurred:\n\n* %s", errs[0])
+	}
+
+	points := make([]string, len(errs))
+	for i, err := range errs {
+		points[i] = fmt.Sprintf("* %s", err)
+	}
+	return fmt.Sprintf("%d errors occurred:\n\n%s", len(errs), strings.Join(points, "\n"))
+}
+
+// Unwrap returns the aggregated errors, which allows for inspecting them using errors.Is and errors.As
+func (errs EncodeErrors) Unwrap() []error {
+	unwrapped := make([]error, len(errs))
+	for i, err := range errs {
+		unwrapped[i] = err
+	}
+	return unwrapped
+}
+
+// IsEncodeErrors checks if the given error is an EncodeErrors error
+// and returns the EncodeErrors error along with a boolean that defines
+// if it is indeed an aggregate of encode errors
+func IsEncodeErrors(err error) (EncodeErrors, bool) {
+	errs, ok := err.(EncodeErrors)
+	return errs, ok
+}
+
+// valueType returns the type of a value, which is nil for invalid values.
+// The type of values stored in interfaces is the type of the stored value.
+func valueType(v reflect.Value) reflect.Type {
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Type()
+}
+
+// fail records an error of a value of type typ. The path of the value is the current
+// path, followed by the given path elements.
+func (e *encodeState) fail(typ reflect.Type, err error, path ...string) {
+	e.errs = append(e.errs, newEncodeError(formatPath(append(e.path[:len(e.path):len(e.path)], path...)), typ, err))
+}
+
+// errorsSince returns the errors recorded since the given number of errors had been recorded,
+// which is nil if no error has been recorded since
+func (e *encodeState) errorsSince(count int) error {
+	if len(e.errs) <= count {
+		return nil
+	}
+	return e.errs[count:len(e.errs):len(e.errs)]
+}
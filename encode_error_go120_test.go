@@ -0,0 +1,29 @@
+//go:build go1.20
+// +build go1.20
+
+package human
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeErrors_ErrorsIsAs(t *testing.T) {
+	enc, err := NewEncoder(bytes.NewBufferString(""))
+	require.NoError(t, err)
+
+	err = enc.Encode(struct {
+		Items []interface{}
+	}{
+		Items: []interface{}{1, failingTextMarshaler{}},
+	})
+	require.Error(t, err)
+	require.True(t, errors.Is(err, errTextMarshalerTest))
+
+	var ee *EncodeError
+	require.True(t, errors.As(err, &ee))
+	require.EqualValues(t, "Items[1]", ee.Path)
+}
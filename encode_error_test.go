@@ -0,0 +1,155 @@
+package human
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// findEncodeError returns the EncodeError with the given path, which must be contained in err
+func findEncodeError(t *testing.T, err error, path string) *EncodeError {
+	errs, ok := IsEncodeErrors(err)
+	require.True(t, ok, "Must be EncodeErrors")
+	for _, ee := range errs {
+		if ee.Path == path {
+			return ee
+		}
+	}
+	require.FailNow(t, "No error with path "+path, "%v", err)
+	return nil
+}
+
+type itemMarshalerTest struct{}
+
+func (itemMarshalerTest) MarshalHuman(w *Writer) error {
+	if err := w.Item("first"); err != nil {
+		return err
+	}
+	return w.Item(failingTextMarshaler{})
+}
+
+func TestIsEncodeError(t *testing.T) {
+	err := newEncodeError("Spec.Size", reflect.TypeOf(0), errMarshalerTest)
+	ee, ok := IsEncodeError(err)
+	require.True(t, ok)
+	require.EqualValues(t, "Spec.Size", ee.Path)
+	require.EqualValues(t, reflect.TypeOf(0), ee.Type)
+	require.EqualValues(t, errMarshalerTest, ee.Err)
+
+	ee, ok = IsEncodeError(errMarshalerTest)
+	require.False(t, ok)
+	require.Nil(t, ee)
+}
+
+func TestEncodeErrorError(t *testing.T) {
+	require.EqualValues(t, "Spec.Size (int): marshaler test error",
+		newEncodeError("Spec.Size", reflect.TypeOf(0), errMarshalerTest).Error())
+	require.EqualValues(t, "Spec.Size: marshaler test error",
+		newEncodeError("Spec.Size", nil, errMarshalerTest).Error())
+	require.EqualValues(t, "(int): marshaler test error",
+		newEncodeError("", reflect.TypeOf(0), errMarshalerTest).Error())
+	require.EqualValues(t, "marshaler test error",
+		newEncodeError("", nil, errMarshalerTest).Error())
+}
+
+func TestEncodeErrorsError(t *testing.T) {
+	errs := EncodeErrors{newEncodeError("A", nil, errMarshalerTest)}
+	require.EqualValues(t, "1 error occurred:\n\n* A: marshaler test error", errs.Error())
+
+	errs = append(errs, newEncodeError("B", nil, errors.New("second")))
+	require.EqualValues(t, "2 errors occurred:\n\n* A: marshaler test error\n* B: second", errs.Error())
+}
+
+func TestEncoder_Encode_EncodeErrors(t *testing.T) {
+	type disk struct {
+		Name string
+		Size failingTextMarshaler
+	}
+	type spec struct {
+		Disks  []disk
+		Labels map[string]interface{}
+	}
+	s := struct {
+		Spec spec
+	}{
+		Spec: spec{
+			Disks:  []disk{{Name: "a"}, {Name: "b"}, {Name: "c"}},
+			Labels: map[string]interface{}{"zone": failingTextMarshaler{}},
+		},
+	}
+
+	enc, err := NewEncoder(bytes.NewBufferString(""))
+	require.NoError(t, err)
+
+	err = enc.Encode(s)
+	require.Error(t, err)
+	errs, ok := IsEncodeErrors(err)
+	require.True(t, ok)
+	require.Len(t, errs, 4)
+
+	ee := findEncodeError(t, err, "Spec.Disks[2].Size")
+	require.EqualValues(t, reflect.TypeOf(failingTextMarshaler{}), ee.Type)
+	require.EqualValues(t, errTextMarshalerTest, ee.Err)
+	require.EqualValues(t, errTextMarshalerTest, findEncodeError(t, err, "Spec.Labels[zone]").Err)
+}
+
+func TestEncoder_Encode_EncodeErrorsMarshalerItem(t *testing.T) {
+	enc, err := NewEncoder(bytes.NewBufferString(""))
+	require.NoError(t, err)
+
+	err = enc.Encode(struct {
+		L itemMarshalerTest
+	}{})
+	require.Error(t, err)
+	errs, ok := IsEncodeErrors(err)
+	require.True(t, ok)
+	require.Len(t, errs, 1)
+	require.EqualValues(t, errTextMarshalerTest, findEncodeError(t, err, "L[1]").Err)
+}
+
+func TestEncodeErrors_IsHelpers(t *testing.T) {
+	type node struct {
+		Invalid string `human:"a\nb"`
+		Unknown string `human:",unknown"`
+		Next    *node
+	}
+	n := &node{}
+	n.Next = n
+
+	enc, err := NewEncoder(bytes.NewBufferString(""), OptionCycleHandling(CycleHandlingError))
+	require.NoError(t, err)
+
+	err = enc.Encode(n)
+	require.Error(t, err)
+
+	ee, ok := IsEncodeError(err)
+	require.True(t, ok)
+	require.EqualValues(t, "Invalid", ee.Path)
+
+	it, ok := IsInvalidTag(err)
+	require.True(t, ok)
+	require.EqualValues(t, "a\nb", it.Tag())
+
+	uto, ok := IsUnknownTagOption(err)
+	require.True(t, ok)
+	require.EqualValues(t, "unknown", uto.Option())
+
+	ce, ok := IsCycleError(err)
+	require.True(t, ok)
+	require.EqualValues(t, "Next", ce.Path())
+
+	// The cause of a single EncodeError is found as well
+	ce, ok = IsCycleError(findEncodeError(t, err, "Next"))
+	require.True(t, ok)
+	require.NotNil(t, ce)
+
+	ce, ok = IsCycleError(errMarshalerTest)
+	require.False(t, ok)
+	require.Nil(t, ce)
+	ce, ok = IsCycleError(nil)
+	require.False(t, ok)
+	require.Nil(t, ce)
+}
@@ -5,41 +5,135 @@ import (
 	"fmt"
 	"io"
 	"reflect"
-	"sort"
+	"strconv"
 	"strings"
-	"unicode"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
-	"github.com/speijnik/go-errortree"
 )
 
 // Encoder writes human readable text to an output stream.
+//
+// An Encoder is safe for concurrent use by multiple goroutines. Each call to Encode
+// renders into its own buffer and the output of concurrent calls is never interleaved.
 type Encoder struct {
-	stream      *FlushableBuffer
+	writer      io.Writer
 	tagName     string
 	indent      uint
 	listSymbols []string
+
+	cycleHandling CycleHandling
+	maxDepth      uint
+	multilineMode MultilineMode
+	tables        bool
+	theme         Theme
+	colorMode     ColorMode
+	colors        bool
+	width         uint
+	streamLimit   uint
+	timeFormat    string
+	timeLocation  *time.Location
+	relativeTime  bool
+	clock         func() time.Time
+
+	// fallbackTagNames holds the tag names which are consulted if a field has no tag named tagName
+	fallbackTagNames   []string
+	fieldNameTransform FieldNameTransform
+	redactor           Redactor
+	sortFields         bool
+	keyLess            KeyLess
+	partialOutput      bool
+
+	typeFormatters      map[reflect.Type]TypeFormatter
+	interfaceFormatters []interfaceFormatter
+
+	// layoutsMu guards layouts, which caches the structLayout of each struct type encoded so far
+	layoutsMu sync.RWMutex
+	layouts   map[reflect.Type]*structLayout
+	// writerMu serializes writes to the writer
+	writerMu sync.Mutex
+	// states holds the encodeState instances that are not in use
+	states sync.Pool
+}
+
+// encodeState holds the state of a single call to Encode
+type encodeState struct {
+	*Encoder
+
+	stream *FlushableBuffer
+	// visited holds the pointers, maps and slices that are currently being encoded
+	visited map[visitKey]struct{}
+	// path holds the path to the value that is currently being encoded
+	path []string
+	// errs holds the errors recorded so far
+	errs EncodeErrors
 }
 
 // Encode writes the human encoding of v to the stream.
+//
+// By default, nothing is written if an error occurs. If streaming is enabled using OptionStreaming,
+// the output generated up to the point the error occurred may already have been written.
+// If partial output is enabled using OptionPartialOutput, values that cannot be encoded are replaced
+// by placeholders and the complete output is written, while the error is returned nevertheless.
+//
+// Errors of values that cannot be encoded are returned as EncodeErrors, which holds an *EncodeError
+// for each of these values.
 func (e *Encoder) Encode(v interface{}) error {
-	value := reflect.ValueOf(v)
-	if err := e.encodeValue(v, value, -1, false); err != nil {
-		e.stream.Reset()
-		return err
+	if e.streamLimit > 0 {
+		// Streamed output is written while encoding, so the writer is locked for the whole call
+		e.writerMu.Lock()
+		defer e.writerMu.Unlock()
 	}
 
-	_, err := e.stream.Flush()
-	e.stream.Reset()
-	return err
+	state := e.newEncodeState()
+	defer e.states.Put(state)
+
+	state.encodeValue(v, reflect.ValueOf(v), -1, false, tagInfo{})
+	encodeErr := state.errorsSince(0)
+	if encodeErr != nil && !e.partialOutput {
+		return encodeErr
+	}
+
+	if e.streamLimit == 0 {
+		e.writerMu.Lock()
+		defer e.writerMu.Unlock()
+	}
+	if _, err := state.stream.Flush(); err != nil {
+		if encodeErr != nil {
+			return append(state.errs, newEncodeError("", nil, err))
+		}
+		return err
+	}
+	return encodeErr
 }
 
-func (e *Encoder) encodeStruct(v reflect.Value, indentLevel int, inList bool) (err error) {
-	t := v.Type()
+// newEncodeState returns an unused encodeState
+func (e *Encoder) newEncodeState() *encodeState {
+	if state, ok := e.states.Get().(*encodeState); ok {
+		state.stream.Reset()
+		state.path = state.path[:0]
+		state.errs = nil
+		for key := range state.visited {
+			delete(state.visited, key)
+		}
+		return state
+	}
+
+	stream := NewFlushableBuffer(e.writer)
+	if e.streamLimit > 0 {
+		stream = NewStreamingBuffer(e.writer, int(e.streamLimit))
+	}
+	return &encodeState{
+		Encoder: e,
+		stream:  stream,
+		visited: make(map[visitKey]struct{}),
+	}
+}
 
+func (e *encodeState) encodeStruct(v reflect.Value, indentLevel int, inList bool) {
 	if v.Kind() == reflect.Ptr && v.IsValid() && !v.IsNil() {
 		v = v.Elem()
-		t = v.Type()
 	}
 
 	if !v.IsValid() {
@@ -47,59 +141,43 @@ func (e *Encoder) encodeStruct(v reflect.Value, indentLevel int, inList bool) (e
 		return
 	}
 
-	for i := 0; i < t.NumField(); i++ {
-		fieldDefinition := t.Field(i)
-		fieldValue := v.Field(i)
-
-		if fieldDefinition.Anonymous {
-			// Anonymous field handling
+	layout := e.structLayout(v.Type())
+	skipped := e.enterAnonymous(v, layout)
+	defer e.leaveAnonymous(v, layout, skipped)
 
-			if fieldValue.Kind() != reflect.Struct && fieldValue.Kind() != reflect.Ptr {
-				// skip anonymous field that is neither a struct, nor a pointer
-				continue
-			} else if fieldValue.Kind() == reflect.Ptr && (!fieldValue.IsValid() || fieldValue.IsNil()) {
-				// skip anonymous nil pointers
-				continue
-			} else if fieldValue.Kind() == reflect.Ptr || fieldValue.Kind() == reflect.Struct {
-				// We are handling a valid pointer or a struct
-
-				if fieldValue.Kind() == reflect.Ptr && fieldValue.Elem().Kind() != reflect.Struct {
-					// Not a struct pointer, skip anonymous field
-					continue
-				} else if fieldValue.Kind() == reflect.Ptr {
-					// Struct pointer, dereference pointer
-					fieldValue = fieldValue.Elem()
-				}
-
-				// Getting this far means we are handling a struct
-				if fieldErr := e.encodeStruct(fieldValue, indentLevel, inList); fieldErr != nil {
-					err = errortree.Add(err, fieldDefinition.Name, fieldErr)
-				}
-
-				// We continue in any way, to skip the handling below
-				continue
-			}
+	for _, field := range layout.invalidFields {
+		// Parsing the tag failed, ignore the field and carry on
+		if field.anonymous < 0 || !skipped[field.anonymous] {
+			e.fail(v.Type().FieldByIndex(field.index).Type, field.info.tagErr, field.info.name)
 		}
+	}
 
-		fieldName := fieldDefinition.Name
+	order := layout.order
+	if layout.orderer {
+		order = e.fieldOrder(v, layout.keys)
+	}
 
-		if !unicode.IsUpper([]rune(fieldName)[0]) {
-			// Ignore private fields
+	for k := range layout.fields {
+		i := k
+		if order != nil {
+			i = order[k]
+		}
+		field := &layout.fields[i]
+		if field.anonymous >= 0 && skipped[field.anonymous] {
 			continue
 		}
 
-		fieldName, omitEmpty, tagErr := parseTagFromStructField(fieldDefinition, e.tagName)
-		if tagErr != nil {
-			// Parsing the tag failed, ignore the field and carry on
-			err = multierror.Append(err, tagErr)
+		fieldValue, ok := fieldByIndex(v, field.index)
+		if !ok {
 			continue
 		}
+		tag := field.info.tag
+		fieldName := layout.keys[i].name
 
 		fieldInterface := fieldValue.Interface()
-		if fieldInterface == nil || fieldName == "-" || (omitEmpty && IsNilOrEmpty(fieldInterface, fieldValue)) {
+		if fieldInterface == nil || (tag.omitEmpty && IsNilOrEmpty(fieldInterface, fieldValue)) {
 			// Skip field if:
 			// - field is a nil-value
-			// - field name specifies that the field shall be omitted
 			// - omitEmpty is set and the field is nil or empty
 			continue
 		}
@@ -107,69 +185,192 @@ func (e *Encoder) encodeStruct(v reflect.Value, indentLevel int, inList bool) (e
 		// Getting this far means we are handling a non-empty field
 		// if the struct is in a list adapt the first element's indent to the list symbol
 		if inList {
-			fmt.Fprint(e.stream, " "+fieldName+":")
+			fmt.Fprint(e.stream, " "+e.colorize(e.theme.Key, fieldName)+":")
 			inList = false
 		} else {
-			fmt.Fprint(e.stream, strings.Repeat(" ", int(e.indent)*indentLevel)+fieldName+":")
-		}
-		if fieldEncodeErr := e.encodeValue(fieldInterface, fieldValue, indentLevel, false); fieldEncodeErr != nil {
-			err = errortree.Add(err, fieldName, fieldEncodeErr)
+			fmt.Fprint(e.stream, strings.Repeat(" ", int(e.indent)*indentLevel)+e.colorize(e.theme.Key, fieldName)+":")
 		}
+		e.path = append(e.path, fieldName)
+		e.encodeValue(fieldInterface, fieldValue, indentLevel, false, tag)
+		e.path = e.path[:len(e.path)-1]
+	}
+
+	if inList {
+		// No field has been written, terminate the list item's line
+		fmt.Fprintln(e.stream, "")
+	}
+}
+
+// structField is a field of a struct value
+type structField struct {
+	value reflect.Value
+	info  fieldInfo
+}
+
+// enterAnonymous marks the anonymous struct pointers of the given struct as being encoded, so
+// cycles passing through them are detected. It returns which of the anonymous struct pointers of
+// the layout are skipped, as they are nil or form a cycle, which is nil if the layout has none.
+func (e *encodeState) enterAnonymous(v reflect.Value, layout *structLayout) (skipped []bool) {
+	if len(layout.anonymous) == 0 {
+		return nil
 	}
 
+	skipped = make([]bool, len(layout.anonymous))
+	for a, anonymous := range layout.anonymous {
+		if anonymous.parent >= 0 && skipped[anonymous.parent] {
+			skipped[a] = true
+			continue
+		}
+
+		anonymousValue, ok := fieldByIndex(v, anonymous.index)
+		if !ok || anonymousValue.IsNil() {
+			// skip anonymous nil pointers
+			skipped[a] = true
+		} else if !e.enter(anonymousValue) {
+			// The struct pointer references one of its parents. Its fields are
+			// written as part of the parent, so there is nothing to mark.
+			skipped[a] = true
+			if e.cycleHandling == CycleHandlingError {
+				e.fail(anonymousValue.Type(), newErrorCycle(formatPath(e.path), anonymousValue.Type()), anonymous.name)
+			}
+		}
+	}
 	return
 }
 
-func (e *Encoder) encodeSlice(v reflect.Value, indentLevel int) error {
+// leaveAnonymous removes the marks set by enterAnonymous
+func (e *encodeState) leaveAnonymous(v reflect.Value, layout *structLayout, skipped []bool) {
+	for a, anonymous := range layout.anonymous {
+		if !skipped[a] {
+			anonymousValue, _ := fieldByIndex(v, anonymous.index)
+			e.leave(anonymousValue)
+		}
+	}
+}
+
+func (e *encodeState) encodeSlice(v reflect.Value, indentLevel int, tag tagInfo) {
 
-	listSymbol := e.listSymbols[int(indentLevel-1)%len(e.listSymbols)]
+	listSymbol := e.colorize(e.theme.ListSymbol, e.listSymbol(indentLevel))
 
 	for i := 0; i < v.Len(); i++ {
 		valueV := v.Index(i)
 		valueI := valueV.Interface()
 		fmt.Fprint(e.stream, strings.Repeat(" ", int(e.indent)*indentLevel)+listSymbol)
-		if err := e.encodeValue(valueI, valueV, indentLevel, true); err != nil {
-			return err
+		e.path = append(e.path, fmt.Sprintf("[%d]", i))
+		e.encodeValue(valueI, valueV, indentLevel, true, tag.elementTag())
+		e.path = e.path[:len(e.path)-1]
+	}
+}
+
+func (e *encodeState) encodeMap(v reflect.Value, indentLevel int, tag tagInfo) {
+
+	listSymbol := e.colorize(e.theme.ListSymbol, e.listSymbol(indentLevel))
+
+	for _, entry := range e.mapEntries(v) {
+		pathKey := entry.text
+		if entry.err != nil {
+			// The key is rendered as placeholder, but its Go representation is used for the path
+			pathKey = fmt.Sprint(entry.key.Interface())
+			e.fail(valueType(entry.key), entry.err, "["+pathKey+"]")
 		}
+
+		valueV := v.MapIndex(entry.key)
+		fmt.Fprint(e.stream, strings.Repeat(" ", int(e.indent)*indentLevel)+listSymbol+" "+e.colorize(e.theme.Key, entry.text)+":")
+		e.path = append(e.path, "["+pathKey+"]")
+		e.encodeValue(valueV.Interface(), valueV, indentLevel, true, tag.elementTag())
+		e.path = e.path[:len(e.path)-1]
 	}
-	return nil
 }
 
-func (e *Encoder) encodeMap(v reflect.Value, indentLevel int) error {
+// encodeValue writes a value. Errors are recorded in the encodeState, along with the path of the
+// value they occurred at, so encoding always carries on with the next value.
+func (e *encodeState) encodeValue(i interface{}, v reflect.Value, indentLevel int, inList bool, tag tagInfo) {
+	// Values stored in interfaces, like the elements of a map[string]interface{}, are
+	// handled like the stored value, so cycles passing through interfaces are detected
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	// Pointers, maps and slices that are already being encoded form a cycle
+	if !e.enter(v) {
+		e.encodeCycle(v)
+		return
+	}
+	defer e.leave(v)
 
-	listSymbol := e.listSymbols[int(indentLevel-1)%len(e.listSymbols)]
+	// At this point it is safe to get rid of a possible pointer...
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		// No-op for nil-pointers
+		return
+	}
 
-	keys := v.MapKeys()
+	// Check if the field's tag requires the value to be redacted. Slices, arrays and maps
+	// pass the redact tag option on to their elements.
+	if tag.redact && !isContainer(reflect.Indirect(v)) {
+		text, redactErr := e.redact(v, tag)
+		if !e.handleError(v, redactErr) {
+			e.encodeText(text, e.theme.String, indentLevel)
+		}
+		return
+	}
 
-	mapKeysStringMap := make(map[string]reflect.Value, len(keys))
-	mapKeyStringList := make([]string, len(keys))
-	for i := 0; i < len(keys); i++ {
-		keyV := keys[i]
-		keyI := keyV.Interface()
-		keyString := fmt.Sprint(keyI)
-		mapKeysStringMap[keyString] = keyV
-		mapKeyStringList[i] = keyString
+	// Check if the field's tag specifies a format for numeric values
+	if text, ok := formatNumber(reflect.Indirect(v), tag.format); ok {
+		e.encodeText(text, e.theme.Number, indentLevel)
+		return
 	}
 
-	sort.Strings(mapKeyStringList)
+	// Check if the value is a time, which is rendered using the field's time tag option
+	// or the encoder's time options, unless a TypeFormatter has been registered
+	formatter, formatterValue, hasFormatter := e.lookupFormatter(v)
+	if t, ok := timeValue(v); ok && (tag.time != "" || !hasFormatter) {
+		e.encodeText(e.formatTime(t, tag.time), e.theme.String, indentLevel)
+		return
+	}
 
-	for _, keyString := range mapKeyStringList {
-		keyV := mapKeysStringMap[keyString]
-		valueV := v.MapIndex(keyV)
-		fmt.Fprint(e.stream, strings.Repeat(" ", int(e.indent)*indentLevel)+listSymbol+" "+keyString+":")
-		if err := e.encodeValue(valueV.Interface(), valueV, indentLevel, true); err != nil {
-			return err
+	// Check if a TypeFormatter has been registered for the value's type, which takes
+	// precedence over all methods implemented by the type
+	if hasFormatter {
+		text, formatErr := formatter(formatterValue)
+		if !e.handleError(v, formatErr) {
+			e.encodeText(text, e.textStyle(formatterValue), indentLevel)
 		}
+		return
 	}
-	return nil
-}
 
-func (e *Encoder) encodeValue(i interface{}, v reflect.Value, indentLevel int, inList bool) (err error) {
-	// At this point it is safe to get rid of a possible pointer...
-	if v.Kind() == reflect.Ptr && !v.IsNil() {
+	if v.Kind() == reflect.Ptr {
 		v = v.Elem()
-	} else if v.Kind() == reflect.Ptr {
-		// No-op for nil-pointers
+	}
+
+	// OrderedMaps are collapsed like maps, before rendering themselves as Marshaler
+	if v.Type() == orderedMapType && e.collapse(v, indentLevel) {
+		return
+	}
+
+	// Check if the passed interface implements Marshaler, in which case the value renders itself
+	// at the nesting level of a struct
+	if marshaler, ok := i.(Marshaler); ok {
+		if !inList {
+			fmt.Fprintln(e.stream, "")
+		}
+		w := &Writer{
+			state:       e,
+			indentLevel: indentLevel + 1,
+			inList:      inList,
+		}
+		errCount := len(e.errs)
+		if marshalErr := marshaler.MarshalHuman(w); marshalErr != nil && len(e.errs) == errCount {
+			// Errors of values written using the Writer have been recorded already,
+			// so only errors of the marshaler itself are recorded
+			e.fail(valueType(v), marshalErr)
+			if e.partialOutput {
+				w.Line(e.errorPlaceholder(marshalErr))
+			}
+		}
+		if w.inList {
+			// Nothing has been written, terminate the list item's line
+			fmt.Fprintln(e.stream, "")
+		}
 		return
 	}
 
@@ -177,14 +378,20 @@ func (e *Encoder) encodeValue(i interface{}, v reflect.Value, indentLevel int, i
 	// for generating the value
 	if marshaler, ok := i.(encoding.TextMarshaler); ok {
 		text, marshalErr := marshaler.MarshalText()
-		if marshalErr != nil {
-			err = marshalErr
+		if !e.handleError(v, marshalErr) {
+			// As MarshalText is expected to return a textual representation, print it to our stream
+			e.encodeText(string(text), e.textStyle(v), indentLevel)
 		}
-		// As MarshalText is expected to return a textual representation, print it to our stream
-		fmt.Fprintf(e.stream, " %s\n", text)
+		return
+	} else if errValue, ok := i.(error); ok {
+		e.encodeText(errValue.Error(), e.theme.Error, indentLevel)
 		return
 	} else if stringer, ok := i.(fmt.Stringer); ok {
-		fmt.Fprintf(e.stream, " %s\n", stringer.String())
+		e.encodeText(stringer.String(), e.textStyle(v), indentLevel)
+		return
+	}
+
+	if e.collapse(v, indentLevel) {
 		return
 	}
 
@@ -195,29 +402,140 @@ func (e *Encoder) encodeValue(i interface{}, v reflect.Value, indentLevel int, i
 		if !inList {
 			fmt.Fprintln(e.stream, "")
 		}
-		err = e.encodeStruct(v, indentLevel+1, inList)
+		e.encodeStruct(v, indentLevel+1, inList)
 	case reflect.Slice, reflect.Array:
-		// Handle slice
+		// Handle slice, which is rendered as table if it holds structs and tables are enabled
 		fmt.Fprintln(e.stream, "")
-		err = e.encodeSlice(v, indentLevel+1)
+		if !(e.tables || tag.table) || !e.isTableSlice(v.Type()) || !e.encodeTable(v, indentLevel+1) {
+			e.encodeSlice(v, indentLevel+1, tag)
+		}
 	case reflect.Map:
 		// Handle map
 		fmt.Fprintln(e.stream, "")
-		err = e.encodeMap(v, indentLevel+1)
+		e.encodeMap(v, indentLevel+1, tag)
+
+	case reflect.String:
+		// Handle string, which may span multiple lines
+		e.encodeText(v.String(), e.theme.String, indentLevel)
 
 	default:
 		// All other types are mapped as-is
 		// missuse Fprint's sepereration spaces to introduce a space in front of the value
-		fmt.Fprintln(e.stream, "", i)
+		fmt.Fprintln(e.stream, "", e.colorize(e.valueStyle(v), fmt.Sprint(i)))
+	}
+}
+
+// encodeText writes a textual value. Multi-line text is rendered according to the
+// configured MultilineMode, each line is colored using the given style.
+func (e *encodeState) encodeText(text string, style Style, indentLevel int) {
+	text = strings.TrimRight(strings.Replace(text, "\r\n", "\n", -1), "\n")
+	if !strings.Contains(text, "\n") {
+		e.writeWrapped(" ", text, style)
+		return
+	}
+
+	switch e.multilineMode {
+	case MultilineQuoted:
+		e.writeWrapped(" ", strconv.Quote(text), style)
+	case MultilineTruncate:
+		e.writeWrapped(" ", firstLine(text), style)
+	default:
+		// Render the text as a block that is indented one level deeper than the current value
+		fmt.Fprintln(e.stream, " |")
+		indent := strings.Repeat(" ", int(e.indent)*(indentLevel+1))
+		for _, line := range strings.Split(text, "\n") {
+			if line == "" {
+				fmt.Fprintln(e.stream, "")
+				continue
+			}
+			e.writeWrapped(indent, line, style)
+		}
+	}
+}
+
+// textStyle returns the style for values which are rendered using their textual
+// representation, falling back to the string style if no other style applies
+func (e *Encoder) textStyle(v reflect.Value) Style {
+	if style := e.valueStyle(v); style != "" {
+		return style
+	}
+	return e.theme.String
+}
+
+// collapse writes the summary of structs, slices and maps nested deeper than the configured
+// maximum depth and returns true, or returns false if the value has not been collapsed
+func (e *encodeState) collapse(v reflect.Value, indentLevel int) bool {
+	if e.maxDepth > 0 && indentLevel+2 > int(e.maxDepth) {
+		if summary, ok := e.summarize(v); ok {
+			fmt.Fprintln(e.stream, "", summary)
+			return true
+		}
+	}
+	return false
+}
+
+// summarize returns a one-line summary for struct, slice and map values, which is used
+// in place of the value's contents. OrderedMaps are summarized like maps.
+// The flag is false for all other kinds.
+func (e *Encoder) summarize(v reflect.Value) (summary string, ok bool) {
+	if v.IsValid() && v.Type() == orderedMapType && v.CanInterface() {
+		m := v.Interface().(OrderedMap)
+		return fmt.Sprintf("<map: %s>", pluralize(m.Len(), "key", "keys")), true
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		fields := pluralize(e.countFields(v.Type()), "field", "fields")
+		if name := v.Type().Name(); name != "" {
+			return fmt.Sprintf("<struct %s: %s>", name, fields), true
+		}
+		return fmt.Sprintf("<struct: %s>", fields), true
+	case reflect.Slice, reflect.Array:
+		return fmt.Sprintf("<%s>", pluralize(v.Len(), "item", "items")), true
+	case reflect.Map:
+		return fmt.Sprintf("<map: %s>", pluralize(v.Len(), "key", "keys")), true
 	}
+	return
+}
+
+// countFields returns the number of fields of a struct type that are not ignored,
+// including the fields of anonymous structs
+func (e *Encoder) countFields(t reflect.Type) int {
+	return e.countUncountedFields(t, map[reflect.Type]bool{})
+}
 
+// countUncountedFields counts the fields of a struct type, skipping anonymous structs that have
+// already been counted
+func (e *Encoder) countUncountedFields(t reflect.Type, counted map[reflect.Type]bool) (count int) {
+	counted[t] = true
+	for _, field := range e.structInfo(t).fields {
+		if field.anonymous {
+			fieldType := t.Field(field.index).Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if !counted[fieldType] {
+				count += e.countUncountedFields(fieldType, counted)
+			}
+		} else if field.tagErr == nil {
+			count++
+		}
+	}
 	return
 }
 
+// listSymbol returns the list symbol for list items at the given indentation level
+func (e *Encoder) listSymbol(indentLevel int) string {
+	if indentLevel < 1 {
+		return e.listSymbols[0]
+	}
+	return e.listSymbols[(indentLevel-1)%len(e.listSymbols)]
+}
+
 // NewEncoder returns a new encoder that writes to w.
 func NewEncoder(w io.Writer, opts ...Option) (encoder *Encoder, err error) {
 	encoder = &Encoder{
-		stream: NewFlushableBuffer(w),
+		writer: w,
 	}
 
 	// apply options
@@ -229,6 +547,9 @@ func NewEncoder(w io.Writer, opts ...Option) (encoder *Encoder, err error) {
 	// check if any option returned an error and set encoder nil
 	if err != nil {
 		encoder = nil
+		return
 	}
+
+	encoder.colors = colorsEnabled(encoder.colorMode, w)
 	return
 }
@@ -1,15 +1,28 @@
 package human
 
 import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
-	"github.com/stretchr/testify/require"
-	"bytes"
 	"github.com/stretchr/testify/assert"
-	"time"
+	"github.com/stretchr/testify/require"
 )
 
+var errMarshalerTest = errors.New("marshaler test error")
+
+type failingMarshaler struct{}
+
+func (failingMarshaler) MarshalHuman(w *Writer) error {
+	w.Line("partial")
+	return errMarshalerTest
+}
+
 func TestNewEncoder(t *testing.T) {
 	t.Run("DefaultOptions", func(t *testing.T) {
 		enc, err := NewEncoder(nil)
@@ -81,3 +94,223 @@ func TestEncoder_Encode(t *testing.T) {
 		assert.EqualValues(t, expectedOutput, outputBuffer.String())
 	})
 }
+
+func TestEncoder_Encode_MarshalerError(t *testing.T) {
+	outputBuffer := bytes.NewBufferString("")
+	enc, err := NewEncoder(outputBuffer)
+	require.NoError(t, err)
+	require.NotNil(t, enc)
+
+	s := struct {
+		Name  string
+		Inner struct {
+			Value failingMarshaler
+		}
+	}{
+		Name: "test",
+	}
+
+	err = enc.Encode(s)
+	require.Error(t, err)
+	require.EqualValues(t, errMarshalerTest, findEncodeError(t, err, "Inner.Value").Err)
+	// Output must be discarded on error
+	require.EqualValues(t, "", outputBuffer.String())
+}
+
+func TestEncoder_Encode_Labels(t *testing.T) {
+	outputBuffer := bytes.NewBufferString("")
+	enc, err := NewEncoder(outputBuffer)
+	require.NoError(t, err)
+	require.NotNil(t, enc)
+
+	s := struct {
+		DiskSize uint   `human:"Disk Size (GB)"`
+		Address  string `human:"'IPv4 address, primary'"`
+	}{
+		DiskSize: 20,
+		Address:  "192.0.2.1",
+	}
+
+	require.NoError(t, enc.Encode(s))
+	require.EqualValues(t, "\nDisk Size (GB): 20\nIPv4 address, primary: 192.0.2.1\n", outputBuffer.String())
+}
+
+func TestEncoder_Encode_FallbackTags(t *testing.T) {
+	outputBuffer := bytes.NewBufferString("")
+	enc, err := NewEncoder(outputBuffer, OptionTagNames(DefaultTagName, "json"))
+	require.NoError(t, err)
+	require.NotNil(t, enc)
+
+	s := struct {
+		Name     string `json:"name"`
+		Comment  string `json:"comment,omitempty"`
+		Password string `json:"-"`
+		Size     int    `human:"Size (GB)" json:"size"`
+	}{
+		Name:     "test",
+		Password: "secret",
+		Size:     20,
+	}
+
+	require.NoError(t, enc.Encode(s))
+	require.EqualValues(t, "\nname: test\nSize (GB): 20\n", outputBuffer.String())
+}
+
+func TestEncoder_Encode_AnonymousError(t *testing.T) {
+	outputBuffer := bytes.NewBufferString("")
+	enc, err := NewEncoder(outputBuffer)
+	require.NoError(t, err)
+	require.NotNil(t, enc)
+
+	type inner struct {
+		Value failingMarshaler `human:",order=1"`
+	}
+	s := struct {
+		Name string
+		inner
+	}{
+		Name: "test",
+	}
+
+	err = enc.Encode(s)
+	require.Error(t, err)
+	require.EqualValues(t, errMarshalerTest, findEncodeError(t, err, "Value").Err)
+}
+
+func TestEncoder_Encode_Multiline(t *testing.T) {
+	s := struct {
+		Name        string
+		Description string
+		Lines       []string
+	}{
+		Name:        "test",
+		Description: "first line\r\n\nthird line\n",
+		Lines:       []string{"single", "multi\nline"},
+	}
+
+	t.Run("Block", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer)
+		require.NoError(t, err)
+
+		expectedOutput := "\nName: test\nDescription: |\n  first line\n\n  third line\nLines:\n  * single\n  * |\n    multi\n    line\n"
+
+		assert.NoError(t, enc.Encode(s))
+		assert.EqualValues(t, expectedOutput, outputBuffer.String())
+	})
+
+	t.Run("Quoted", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer, OptionMultilineMode(MultilineQuoted))
+		require.NoError(t, err)
+
+		expectedOutput := "\nName: test\nDescription: \"first line\\n\\nthird line\"\nLines:\n  * single\n  * \"multi\\nline\"\n"
+
+		assert.NoError(t, enc.Encode(s))
+		assert.EqualValues(t, expectedOutput, outputBuffer.String())
+	})
+
+	t.Run("Truncate", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer, OptionMultilineMode(MultilineTruncate))
+		require.NoError(t, err)
+
+		expectedOutput := "\nName: test\nDescription: first line ...\nLines:\n  * single\n  * multi ...\n"
+
+		assert.NoError(t, enc.Encode(s))
+		assert.EqualValues(t, expectedOutput, outputBuffer.String())
+	})
+}
+
+type benchmarkRow struct {
+	Name   string
+	Status string `human:"status"`
+	CPUs   int    `human:"cpus,omitempty"`
+	Memory uint64
+	Tags   []string
+}
+
+func BenchmarkEncoder_Encode(b *testing.B) {
+	rows := make([]benchmarkRow, 1000)
+	for i := range rows {
+		rows[i] = benchmarkRow{
+			Name:   "vm",
+			Status: "running",
+			CPUs:   i % 4,
+			Memory: 1024,
+			Tags:   []string{"a", "b"},
+		}
+	}
+
+	enc, err := NewEncoder(ioutil.Discard)
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := enc.Encode(rows); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEncoder_Encode_Streaming(t *testing.T) {
+	outputBuffer := bytes.NewBufferString("")
+	enc, err := NewEncoder(outputBuffer, OptionStreaming(1))
+	require.NoError(t, err)
+	require.NotNil(t, enc)
+
+	s := struct {
+		Name  string
+		Value failingMarshaler
+		Other string
+	}{
+		Name:  "test",
+		Other: "other",
+	}
+
+	// Output written before the error occurred has already been streamed
+	require.Error(t, enc.Encode(s))
+	require.EqualValues(t, "\nName: test\nValue:\n  partial\nOther: other\n", outputBuffer.String())
+
+	outputBuffer.Reset()
+	require.NoError(t, enc.Encode(s.Name))
+	require.EqualValues(t, " test\n", outputBuffer.String())
+}
+
+func TestEncoder_Encode_Concurrent(t *testing.T) {
+	s := struct {
+		Name  string
+		Lines []string
+		Map   map[string]int
+	}{
+		Name:  "test",
+		Lines: []string{"one", "two", "three"},
+		Map:   map[string]int{"a": 1, "b": 2},
+	}
+	expectedOutput := "\nName: test\nLines:\n  * one\n  * two\n  * three\nMap:\n  * a: 1\n  * b: 2\n"
+
+	for name, opts := range map[string][]Option{
+		"Buffered":  nil,
+		"Streaming": {OptionStreaming(8)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			outputBuffer := bytes.NewBufferString("")
+			enc, err := NewEncoder(outputBuffer, opts...)
+			require.NoError(t, err)
+
+			const goroutines = 16
+			var wg sync.WaitGroup
+			for i := 0; i < goroutines; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					assert.NoError(t, enc.Encode(s))
+				}()
+			}
+			wg.Wait()
+
+			// The output of concurrent calls must not be interleaved
+			require.EqualValues(t, strings.Repeat(expectedOutput, goroutines), outputBuffer.String())
+		})
+	}
+}
@@ -7,3 +7,31 @@ var ErrInvalidTagName = errors.New("invalid tag name")
 
 // ErrListSymbolsEmpty indicates that no list symbols were provided.
 var ErrListSymbolsEmpty = errors.New("no list symbols provided")
+
+// ErrInvalidCycleHandling indicates that an unknown cycle handling was specified.
+var ErrInvalidCycleHandling = errors.New("invalid cycle handling")
+
+// ErrInvalidMultilineMode indicates that an unknown multi-line mode was specified.
+var ErrInvalidMultilineMode = errors.New("invalid multi-line mode")
+
+// ErrInvalidColorMode indicates that an unknown color mode was specified.
+var ErrInvalidColorMode = errors.New("invalid color mode")
+
+// ErrInvalidTypeFormatter indicates that a type formatter was registered without type or function,
+// or for a type that is not an interface where an interface type is required.
+var ErrInvalidTypeFormatter = errors.New("invalid type formatter")
+
+// ErrInvalidTimeFormat indicates that no time format was specified.
+var ErrInvalidTimeFormat = errors.New("invalid time format")
+
+// ErrInvalidClock indicates that no clock function was specified.
+var ErrInvalidClock = errors.New("invalid clock")
+
+// ErrInvalidFieldNameTransform indicates that no field name transform function was specified.
+var ErrInvalidFieldNameTransform = errors.New("invalid field name transform")
+
+// ErrInvalidRedactor indicates that no redactor function was specified.
+var ErrInvalidRedactor = errors.New("invalid redactor")
+
+// ErrInvalidKeyLess indicates that no map key comparison function was specified.
+var ErrInvalidKeyLess = errors.New("invalid key comparison function")
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"time"
 
 	human "github.com/anexia-it/go-human"
 )
@@ -51,7 +52,7 @@ type TextMarshalerPointerTest struct {
 
 // TagFailTest test struct
 type TagFailTest struct {
-	Test int `human:"&§/$"` // invalid tag name
+	Test int `human:"'unterminated"` // invalid tag name
 }
 
 // AnonymousFieldTest test struct
@@ -80,6 +81,25 @@ type AnonymousStructPtrTest struct {
 	Outer string
 }
 
+// QuotaTest implements the human.Marshaler interface
+type QuotaTest struct {
+	Used  uint64
+	Limit uint64
+}
+
+// MarshalHuman renders the quota as multiple lines
+func (q QuotaTest) MarshalHuman(w *human.Writer) error {
+	w.Line(fmt.Sprintf("%d of %d used", q.Used, q.Limit))
+	return w.Field("Free", q.Limit-q.Used)
+}
+
+// MarshalerTest test struct
+type MarshalerTest struct {
+	Name   string
+	Quota  QuotaTest
+	Quotas []QuotaTest
+}
+
 // Encode test with simple test struct to test encode with ignored fields and omit if field is empty
 func ExampleEncoder_Encode_simpleOmitEmpty() {
 	enc, err := human.NewEncoder(os.Stdout)
@@ -177,8 +197,8 @@ func ExampleEncoder_Encode_simpleMap() {
 	//     Property2: 4.5
 	//   * Two: Name: Person2
 	// StructMap:
-	//   * {Person1 0 4.5}: 1
-	//   * {Person2 0 0}: 2
+	//   * Name=Person1, Property2=4.5: 1
+	//   * Name=Person2: 2
 }
 
 // Encode test with slice of integers and slice of structs
@@ -309,7 +329,7 @@ func ExampleEncoder_Encode_tagError() {
 
 	// Output: ERROR: 1 error occurred:
 	//
-	// * Invalid tag: '&§/$'
+	// * Test (int): Invalid tag: ''unterminated'
 	//
 }
 
@@ -429,4 +449,195 @@ func ExampleEncoder_Encode_AnonymousStruct() {
 	if err = enc.Encode(outer); err != nil {
 		fmt.Printf("ERROR: %s\n", err.Error())
 	}
-}
\ No newline at end of file
+}
+
+// Encode test with human.Marshaler implemented by field
+func ExampleEncoder_Encode_marshaler() {
+	enc, err := human.NewEncoder(os.Stdout)
+	if err != nil {
+		return
+	}
+
+	testStruct := MarshalerTest{
+		Name:  "test",
+		Quota: QuotaTest{Used: 3, Limit: 10},
+		Quotas: []QuotaTest{
+			{Used: 1, Limit: 2},
+			{Used: 0, Limit: 5},
+		},
+	}
+
+	if err := enc.Encode(testStruct); err != nil {
+		fmt.Printf("ERROR: %s\n", err.Error())
+		return
+	}
+
+	// Output: Name: test
+	// Quota:
+	//   3 of 10 used
+	//   Free: 7
+	// Quotas:
+	//   * 1 of 2 used
+	//     Free: 1
+	//   * 0 of 5 used
+	//     Free: 5
+}
+
+// Encode test with collapsed values below the maximum depth
+func ExampleEncoder_Encode_maxDepth() {
+	enc, err := human.NewEncoder(os.Stdout, human.OptionMaxDepth(1))
+	if err != nil {
+		return
+	}
+
+	testStruct := struct {
+		Name   string
+		Child  SimpleChild
+		Slice  SliceTest
+		Values []int
+		Map    map[string]int
+	}{
+		Name:   "test",
+		Values: []int{1, 2, 3},
+		Map:    map[string]int{"one": 1},
+	}
+
+	if err := enc.Encode(testStruct); err != nil {
+		fmt.Printf("ERROR: %s\n", err.Error())
+		return
+	}
+
+	// Output: Name: test
+	// Child: <struct SimpleChild: 2 fields>
+	// Slice: <struct SliceTest: 2 fields>
+	// Values: <3 items>
+	// Map: <map: 1 key>
+}
+
+// VMTest test struct
+type VMTest struct {
+	Name   string
+	Status string
+	CPUs   int    `human:"cpus"`
+	Note   string `human:",omitempty"`
+	Secret string `human:"-"`
+}
+
+// VMListTest test struct
+type VMListTest struct {
+	VMs []VMTest `human:",table"`
+}
+
+// Encode test with slice of structs rendered as table
+func ExampleEncoder_Encode_table() {
+	enc, err := human.NewEncoder(os.Stdout)
+	if err != nil {
+		return
+	}
+
+	testStruct := VMListTest{
+		VMs: []VMTest{
+			{Name: "vm1", Status: "running", CPUs: 2},
+			{Name: "database", Status: "stopped", CPUs: 16},
+		},
+	}
+
+	if err := enc.Encode(testStruct); err != nil {
+		fmt.Printf("ERROR: %s\n", err.Error())
+		return
+	}
+
+	// Output: VMs:
+	//   Name      Status   cpus
+	//   vm1       running  2
+	//   database  stopped  16
+}
+
+// Sprint test with simple test struct
+func ExampleSprint() {
+	testStruct := SimpleTest{
+		Var1: "v1",
+		Var2: 2,
+		Child: SimpleChild{
+			Name: "theChild",
+		},
+	}
+
+	text, err := human.Sprint(testStruct)
+	if err != nil {
+		fmt.Printf("ERROR: %s\n", err.Error())
+		return
+	}
+	fmt.Print(text)
+
+	// Output: Var1: v1
+	// variable_2: 2
+	// Child:
+	//   Name: theChild
+}
+
+// QuotaUsageTest test struct
+type QuotaUsageTest struct {
+	Memory  uint64        `human:",format=bytes"`
+	Disks   []uint64      `human:",format=bytes"`
+	Uptime  int64         `human:",format=duration"`
+	Timeout time.Duration `human:",format=duration"`
+	Queries int           `human:",format=si"`
+	Usage   float64       `human:",format=percent"`
+}
+
+// Encode test with humanized number formats
+func ExampleEncoder_Encode_format() {
+	enc, err := human.NewEncoder(os.Stdout)
+	if err != nil {
+		return
+	}
+
+	testStruct := QuotaUsageTest{
+		Memory:  1536 << 20,
+		Disks:   []uint64{10 << 30, 512 << 20},
+		Uptime:  11520,
+		Timeout: 90 * time.Second,
+		Queries: 12400,
+		Usage:   87.5,
+	}
+
+	if err := enc.Encode(testStruct); err != nil {
+		fmt.Printf("ERROR: %s\n", err.Error())
+		return
+	}
+
+	// Output: Memory: 1.5 GiB
+	// Disks:
+	//   * 10 GiB
+	//   * 512 MiB
+	// Uptime: 3h12m
+	// Timeout: 1m30s
+	// Queries: 12.4k
+	// Usage: 87.5 %
+}
+
+// Encode test with an insertion-ordered map
+func ExampleOrderedMap() {
+	enc, err := human.NewEncoder(os.Stdout)
+	if err != nil {
+		return
+	}
+
+	status := human.NewOrderedMap().
+		Set("Status", "running").
+		Set("Name", "web-1").
+		SetOmitEmpty("Comment", "").
+		Set("Addresses", []string{"192.0.2.1", "192.0.2.2"})
+
+	if err := enc.Encode(status); err != nil {
+		fmt.Printf("ERROR: %s\n", err.Error())
+		return
+	}
+
+	// Output: Status: running
+	// Name: web-1
+	// Addresses:
+	//   * 192.0.2.1
+	//   * 192.0.2.2
+}
@@ -0,0 +1,255 @@
+package human
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// fieldInfo holds the information of a struct field that is required for encoding it
+type fieldInfo struct {
+	// index is the index of the field within its struct
+	index int
+	// name is the name of the field in Go
+	name string
+	// anonymous defines if the field is an anonymous struct or struct pointer,
+	// whose fields are encoded as if they were fields of the outer struct
+	anonymous bool
+	// tag holds the parsed tag of the field
+	tag tagInfo
+	// tagErr holds the error returned when parsing the tag, in which case the field is not encoded
+	tagErr error
+}
+
+// structInfo holds the information of a struct type that is required for encoding it
+type structInfo struct {
+	// fields holds the fields which are not ignored, in order of declaration
+	fields []fieldInfo
+}
+
+// structInfoKey identifies a struct type in combination with the options that affect
+// the structInfo
+type structInfoKey struct {
+	t       reflect.Type
+	tagName string
+	// fallbackTagNames holds the comma-separated fallback tag names
+	fallbackTagNames string
+}
+
+// structInfoCache caches the structInfo of all struct types encountered so far
+var structInfoCache struct {
+	sync.RWMutex
+	m map[structInfoKey]*structInfo
+}
+
+// structInfo returns the structInfo for the given struct type, using the Encoder's tag names
+func (e *Encoder) structInfo(t reflect.Type) *structInfo {
+	return cachedStructInfo(t, e.tagName, e.fallbackTagNames...)
+}
+
+// cachedStructInfo returns the structInfo for the given struct type, which is compiled
+// on first use and cached afterwards
+func cachedStructInfo(t reflect.Type, tagName string, fallbackTagNames ...string) *structInfo {
+	key := structInfoKey{
+		t:                t,
+		tagName:          tagName,
+		fallbackTagNames: strings.Join(fallbackTagNames, ","),
+	}
+
+	structInfoCache.RLock()
+	info, ok := structInfoCache.m[key]
+	structInfoCache.RUnlock()
+	if ok {
+		return info
+	}
+
+	info = compileStructInfo(t, tagName, fallbackTagNames...)
+
+	structInfoCache.Lock()
+	if structInfoCache.m == nil {
+		structInfoCache.m = make(map[structInfoKey]*structInfo)
+	}
+	structInfoCache.m[key] = info
+	structInfoCache.Unlock()
+	return info
+}
+
+// compileStructInfo walks the fields of the given struct type and parses their tags
+func compileStructInfo(t reflect.Type, tagName string, fallbackTagNames ...string) *structInfo {
+	info := &structInfo{}
+
+	for i := 0; i < t.NumField(); i++ {
+		fieldDefinition := t.Field(i)
+
+		if fieldDefinition.Anonymous {
+			// Anonymous fields are only encoded if they are structs or struct pointers
+			fieldType := fieldDefinition.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() != reflect.Struct {
+				continue
+			}
+
+			info.fields = append(info.fields, fieldInfo{
+				index:     i,
+				name:      fieldDefinition.Name,
+				anonymous: true,
+			})
+			continue
+		}
+
+		if !unicode.IsUpper([]rune(fieldDefinition.Name)[0]) {
+			// Ignore private fields
+			continue
+		}
+
+		tag, tagErr := parseTagFromStructField(fieldDefinition, tagName, fallbackTagNames...)
+		if tagErr == nil && tag.ignore {
+			// Ignore fields which shall be omitted
+			continue
+		}
+
+		info.fields = append(info.fields, fieldInfo{
+			index:  i,
+			name:   fieldDefinition.Name,
+			tag:    tag,
+			tagErr: tagErr,
+		})
+	}
+
+	return info
+}
+
+// structLayout holds the fields of a struct type which are encoded, including the fields of
+// anonymous structs, along with the order they are rendered in. In contrast to structInfo,
+// the structLayout depends on all options of the Encoder which affect the names and order of fields.
+type structLayout struct {
+	// fields holds the fields whose tag could be parsed, in order of declaration
+	fields []layoutField
+	// invalidFields holds the fields whose tag could not be parsed
+	invalidFields []layoutField
+	// anonymous holds the anonymous struct pointers, each following the one it is contained in
+	anonymous []layoutAnonymous
+	// keys holds the orderKey of each of the fields
+	keys []orderKey
+	// order holds the indices of the fields in the order they are rendered in, or nil if they are
+	// rendered in order of declaration. It is not used if orderer is set.
+	order []int
+	// orderer defines if the struct type implements FieldOrderer, in which case the order
+	// is determined for each value
+	orderer bool
+}
+
+// layoutField is a field of a structLayout
+type layoutField struct {
+	// index holds the indices leading to the field, like reflect.StructField.Index
+	index []int
+	// anonymous is the index of the innermost anonymous struct pointer containing the field,
+	// or -1 if the field is not contained in an anonymous struct pointer
+	anonymous int
+	info      *fieldInfo
+}
+
+// layoutAnonymous is an anonymous struct pointer of a structLayout
+type layoutAnonymous struct {
+	// index holds the indices leading to the struct pointer
+	index []int
+	// parent is the index of the anonymous struct pointer containing this one, or -1
+	parent int
+	// name is the Go name of the field
+	name string
+}
+
+var fieldOrdererType = reflect.TypeOf((*FieldOrderer)(nil)).Elem()
+
+// structLayout returns the structLayout for the given struct type, which is compiled on first
+// use and cached by the Encoder afterwards
+func (e *Encoder) structLayout(t reflect.Type) *structLayout {
+	e.layoutsMu.RLock()
+	layout, ok := e.layouts[t]
+	e.layoutsMu.RUnlock()
+	if ok {
+		return layout
+	}
+
+	layout = e.compileStructLayout(t)
+
+	e.layoutsMu.Lock()
+	if e.layouts == nil {
+		e.layouts = make(map[reflect.Type]*structLayout)
+	}
+	e.layouts[t] = layout
+	e.layoutsMu.Unlock()
+	return layout
+}
+
+// compileStructLayout flattens the fields of the given struct type and determines their order
+func (e *Encoder) compileStructLayout(t reflect.Type) *structLayout {
+	layout := &structLayout{
+		orderer: t.Implements(fieldOrdererType) || reflect.PtrTo(t).Implements(fieldOrdererType),
+	}
+	e.collectLayoutFields(layout, t, nil, -1, map[reflect.Type]bool{})
+
+	layout.keys = make([]orderKey, len(layout.fields))
+	for i, field := range layout.fields {
+		layout.keys[i] = orderKey{
+			goName: field.info.name,
+			name:   e.fieldName(field.info.tag),
+			tag:    field.info.tag,
+		}
+	}
+
+	if !layout.orderer && (e.sortFields || hasOrderedField(layout.keys)) {
+		layout.order = e.fieldOrder(reflect.Value{}, layout.keys)
+	}
+	return layout
+}
+
+// collectLayoutFields adds the fields of the given struct type to the layout, descending into
+// anonymous structs whose type has not been visited yet.
+// The index holds the indices leading to the struct, anonymous is the index of the innermost
+// anonymous struct pointer containing it.
+func (e *Encoder) collectLayoutFields(layout *structLayout, t reflect.Type, index []int, anonymous int, visited map[reflect.Type]bool) {
+	visited[t] = true
+	info := e.structInfo(t)
+	for i := range info.fields {
+		field := &info.fields[i]
+		fieldIndex := append(index[:len(index):len(index)], field.index)
+
+		if !field.anonymous {
+			layoutField := layoutField{
+				index:     fieldIndex,
+				anonymous: anonymous,
+				info:      field,
+			}
+			if field.tagErr != nil {
+				layout.invalidFields = append(layout.invalidFields, layoutField)
+			} else {
+				layout.fields = append(layout.fields, layoutField)
+			}
+			continue
+		}
+
+		fieldType := t.Field(field.index).Type
+		isPtr := fieldType.Kind() == reflect.Ptr
+		if isPtr {
+			fieldType = fieldType.Elem()
+		}
+		if visited[fieldType] {
+			continue
+		}
+
+		parent := anonymous
+		if isPtr {
+			layout.anonymous = append(layout.anonymous, layoutAnonymous{
+				index:  fieldIndex,
+				parent: anonymous,
+				name:   field.name,
+			})
+			parent = len(layout.anonymous) - 1
+		}
+		e.collectLayoutFields(layout, fieldType, fieldIndex, parent, visited)
+	}
+}
@@ -0,0 +1,150 @@
+package human
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fieldsEmbeddedTest struct {
+	Embedded string
+}
+
+type fieldsTest struct {
+	fieldsEmbeddedTest
+	int
+	Name     string
+	Ignored  string `human:"-"`
+	Renamed  string `human:"renamed,omitempty"`
+	Invalid  string `human:"a\nb"`
+	private  string
+	Children []fieldsTest
+}
+
+type fieldsFallbackTest struct {
+	Human    string `human:"human" json:"json"`
+	JSON     string `json:"json_name,omitempty,string" yaml:"yaml_name"`
+	YAML     string `yaml:"yaml_name,flow"`
+	Ignored  string `json:"-"`
+	Dash     string `json:"-,"`
+	Explicit string `human:"" json:"explicit"`
+	Quoted   string `human:"'-'"`
+	Plain    string
+}
+
+func TestCompileStructInfoFallback(t *testing.T) {
+	info := compileStructInfo(reflect.TypeOf(fieldsFallbackTest{}), DefaultTagName, "json", "yaml")
+	require.Len(t, info.fields, 7)
+
+	require.EqualValues(t, "human", info.fields[0].tag.name)
+	require.EqualValues(t, "json_name", info.fields[1].tag.name)
+	require.True(t, info.fields[1].tag.omitEmpty)
+	require.NoError(t, info.fields[1].tagErr)
+	require.EqualValues(t, "yaml_name", info.fields[2].tag.name)
+	require.NoError(t, info.fields[2].tagErr)
+	require.EqualValues(t, "-", info.fields[3].tag.name)
+	require.EqualValues(t, "Explicit", info.fields[4].tag.name)
+	require.EqualValues(t, "-", info.fields[5].tag.name)
+	require.EqualValues(t, "Plain", info.fields[6].tag.name)
+	require.True(t, info.fields[6].tag.implicitName)
+
+	// Without fallback tags, only the human tag is consulted
+	info = compileStructInfo(reflect.TypeOf(fieldsFallbackTest{}), DefaultTagName)
+	require.Len(t, info.fields, 8)
+	require.EqualValues(t, "JSON", info.fields[1].tag.name)
+}
+
+func TestCompileStructInfo(t *testing.T) {
+	info := compileStructInfo(reflect.TypeOf(fieldsTest{}), DefaultTagName)
+	require.Len(t, info.fields, 5)
+
+	require.True(t, info.fields[0].anonymous)
+	require.EqualValues(t, "fieldsEmbeddedTest", info.fields[0].name)
+
+	require.EqualValues(t, 2, info.fields[1].index)
+	require.EqualValues(t, "Name", info.fields[1].tag.name)
+
+	require.EqualValues(t, 4, info.fields[2].index)
+	require.EqualValues(t, "renamed", info.fields[2].tag.name)
+	require.True(t, info.fields[2].tag.omitEmpty)
+
+	require.EqualValues(t, "Invalid", info.fields[3].name)
+	_, isInvalid := IsInvalidTag(info.fields[3].tagErr)
+	require.True(t, isInvalid)
+
+	require.EqualValues(t, 7, info.fields[4].index)
+}
+
+func TestCachedStructInfo(t *testing.T) {
+	typ := reflect.TypeOf(fieldsTest{})
+
+	var wg sync.WaitGroup
+	infos := make([]*structInfo, 8)
+	for i := range infos {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			infos[i] = cachedStructInfo(typ, DefaultTagName)
+		}(i)
+	}
+	wg.Wait()
+
+	// Once cached, the same structInfo is returned
+	info := cachedStructInfo(typ, DefaultTagName)
+	require.True(t, info == cachedStructInfo(typ, DefaultTagName))
+	require.EqualValues(t, info, infos[0])
+
+	// The tag name is part of the cache key
+	require.False(t, info == cachedStructInfo(typ, "test"))
+	require.False(t, info == cachedStructInfo(typ, DefaultTagName, "json"))
+}
+
+type fieldsRecursiveTest struct {
+	*fieldsRecursiveTest
+	*fieldsEmbeddedTest
+	Name string `human:",order=1"`
+}
+
+func TestEncoder_structLayout(t *testing.T) {
+	enc, err := NewEncoder(nil)
+	require.NoError(t, err)
+
+	// Fields keep their order of declaration, anonymous structs of a visited type are skipped
+	layout := enc.structLayout(reflect.TypeOf(fieldsTest{}))
+	require.True(t, layout == enc.structLayout(reflect.TypeOf(fieldsTest{})))
+	require.Nil(t, layout.order)
+	require.False(t, layout.orderer)
+	require.Len(t, layout.fields, 4)
+	require.EqualValues(t, []int{0, 0}, layout.fields[0].index)
+	require.EqualValues(t, "renamed", layout.keys[2].name)
+	require.Len(t, layout.invalidFields, 1)
+	require.Empty(t, layout.anonymous)
+
+	layout = enc.structLayout(reflect.TypeOf(fieldsRecursiveTest{}))
+	require.Len(t, layout.anonymous, 1)
+	require.EqualValues(t, "fieldsEmbeddedTest", layout.anonymous[0].name)
+	require.Len(t, layout.fields, 2)
+	require.EqualValues(t, 0, layout.fields[0].anonymous)
+	require.EqualValues(t, []int{1, 0}, layout.order)
+
+	// FieldOrderer implementations are ordered for each value
+	layout = enc.structLayout(reflect.TypeOf(orderPointerMethodTest{}))
+	require.True(t, layout.orderer)
+	require.Nil(t, layout.order)
+}
+
+func BenchmarkCompileStructInfo(b *testing.B) {
+	typ := reflect.TypeOf(fieldsTest{})
+	for i := 0; i < b.N; i++ {
+		compileStructInfo(typ, DefaultTagName)
+	}
+}
+
+func BenchmarkCachedStructInfo(b *testing.B) {
+	typ := reflect.TypeOf(fieldsTest{})
+	for i := 0; i < b.N; i++ {
+		cachedStructInfo(typ, DefaultTagName)
+	}
+}
@@ -11,17 +11,46 @@ import (
 //
 // This allows for delay of actual writing to the io.Buffer, providing
 // an interface similar to the io.Writer.
+//
+// If a limit is set, the FlushableBuffer does not hold back the complete
+// output until Flush is called, but writes all complete lines to the underlying
+// stream as soon as more than limit bytes are buffered.
 type FlushableBuffer struct {
 	*bytes.Buffer
 	stream io.Writer
+	limit  int
+	err    error
+}
+
+// Write appends p to the buffer. If a limit is set and exceeded, all complete lines
+// are written to the underlying stream.
+//
+// Errors returned by the underlying stream are deferred until Flush is called.
+func (b *FlushableBuffer) Write(p []byte) (n int, err error) {
+	n, err = b.Buffer.Write(p)
+	if b.limit > 0 && b.Len() > b.limit && b.err == nil {
+		if lineEnd := bytes.LastIndexByte(b.Bytes(), '\n'); lineEnd >= 0 {
+			_, b.err = b.stream.Write(b.Next(lineEnd + 1))
+		}
+	}
+	return
 }
 
 // Flush writes the data written to the FlushableBuffer to the underlying
 // stream.
 func (b *FlushableBuffer) Flush() (n int, err error) {
+	if b.err != nil {
+		return 0, b.err
+	}
 	return b.stream.Write(b.Bytes())
 }
 
+// Reset discards the buffered data and any deferred error.
+func (b *FlushableBuffer) Reset() {
+	b.Buffer.Reset()
+	b.err = nil
+}
+
 // NewFlushableBuffer creates a new FlushableBuffer for a given io.Writer
 func NewFlushableBuffer(stream io.Writer) *FlushableBuffer {
 	return &FlushableBuffer{
@@ -29,3 +58,11 @@ func NewFlushableBuffer(stream io.Writer) *FlushableBuffer {
 		stream: stream,
 	}
 }
+
+// NewStreamingBuffer creates a new FlushableBuffer for a given io.Writer, which
+// writes complete lines to the io.Writer once more than limit bytes are buffered
+func NewStreamingBuffer(stream io.Writer, limit int) *FlushableBuffer {
+	b := NewFlushableBuffer(stream)
+	b.limit = limit
+	return b
+}
@@ -0,0 +1,61 @@
+package human
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestFlushableBuffer(t *testing.T) {
+	output := bytes.NewBufferString("")
+	b := NewFlushableBuffer(output)
+
+	fmt.Fprint(b, "line 1\nline 2\n")
+	require.EqualValues(t, "", output.String())
+
+	n, err := b.Flush()
+	require.NoError(t, err)
+	require.EqualValues(t, 14, n)
+	require.EqualValues(t, "line 1\nline 2\n", output.String())
+}
+
+func TestStreamingBuffer(t *testing.T) {
+	output := bytes.NewBufferString("")
+	b := NewStreamingBuffer(output, 8)
+
+	fmt.Fprint(b, "line 1\n")
+	require.EqualValues(t, "", output.String())
+
+	// Exceeding the limit writes all complete lines
+	fmt.Fprint(b, "line 2\nline")
+	require.EqualValues(t, "line 1\nline 2\n", output.String())
+	require.EqualValues(t, "line", b.String())
+
+	fmt.Fprint(b, " 3\n")
+	_, err := b.Flush()
+	require.NoError(t, err)
+	require.EqualValues(t, "line 1\nline 2\nline 3\n", output.String())
+}
+
+func TestStreamingBufferError(t *testing.T) {
+	b := NewStreamingBuffer(failingWriter{}, 1)
+
+	// Errors are deferred until Flush is called
+	_, err := fmt.Fprint(b, "line 1\n")
+	require.NoError(t, err)
+
+	_, err = b.Flush()
+	require.EqualError(t, err, "write failed")
+
+	b.Reset()
+	require.NoError(t, b.err)
+}
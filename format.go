@@ -0,0 +1,156 @@
+package human
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formats supported by the "format" tag option
+const (
+	// FormatBytes renders numbers as byte sizes using binary prefixes, like "1.5 GiB"
+	FormatBytes = "bytes"
+	// FormatDuration renders numbers as durations, like "3h12m". time.Duration values are
+	// used as they are, all other numbers are interpreted as seconds.
+	FormatDuration = "duration"
+	// FormatSI renders numbers using SI prefixes, like "12.4k"
+	FormatSI = "si"
+	// FormatPercent renders numbers as percentage, like "87.5 %". The number is expected to be
+	// a percentage already, so 87.5 is rendered as "87.5 %".
+	FormatPercent = "percent"
+)
+
+// isValidFormat checks if the given format is supported
+func isValidFormat(format string) bool {
+	switch format {
+	case FormatBytes, FormatDuration, FormatSI, FormatPercent:
+		return true
+	}
+	return false
+}
+
+var (
+	binaryPrefixes = []string{"", "Ki", "Mi", "Gi", "Ti", "Pi", "Ei"}
+	siPrefixes     = []string{"", "k", "M", "G", "T", "P", "E"}
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// formatNumber renders a numeric value according to the given format.
+// The flag is false if no format is given or the value is not numeric.
+func formatNumber(v reflect.Value, format string) (text string, ok bool) {
+	if format == "" {
+		return
+	}
+
+	var number float64
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		number = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		number = v.Float()
+	default:
+		return
+	}
+
+	switch format {
+	case FormatBytes:
+		return formatWithPrefix(number, 1024, binaryPrefixes, " ") + "B", true
+	case FormatSI:
+		return formatWithPrefix(number, 1000, siPrefixes, ""), true
+	case FormatPercent:
+		return formatDecimal(number) + " %", true
+	case FormatDuration:
+		if v.Type() == durationType {
+			return formatDuration(time.Duration(v.Int())), true
+		}
+		// Numbers of seconds exceeding the range of time.Duration must not be converted
+		nanoseconds := number * float64(time.Second)
+		if math.IsNaN(nanoseconds) || math.Abs(nanoseconds) >= math.MaxInt64 {
+			return formatSeconds(number), true
+		}
+		return formatDuration(time.Duration(nanoseconds)), true
+	}
+	return
+}
+
+// formatWithPrefix scales a number by the given base until it is below the base
+// and appends the corresponding prefix
+func formatWithPrefix(number, base float64, prefixes []string, separator string) string {
+	exponent := 0
+	for math.Abs(number) >= base && exponent < len(prefixes)-1 {
+		number /= base
+		exponent++
+	}
+	if exponent == 0 && separator == "" {
+		return formatDecimal(number)
+	}
+	return formatDecimal(number) + separator + prefixes[exponent]
+}
+
+// formatDecimal renders a number with at most one decimal place
+func formatDecimal(number float64) string {
+	return strings.TrimSuffix(strconv.FormatFloat(number, 'f', 1, 64), ".0")
+}
+
+// durationUnits defines the units used for rendering durations, from largest to smallest
+var durationUnits = []struct {
+	suffix   string
+	duration uint64
+}{
+	{"d", uint64(24 * time.Hour)},
+	{"h", uint64(time.Hour)},
+	{"m", uint64(time.Minute)},
+	{"s", uint64(time.Second)},
+}
+
+// formatDuration renders a duration using its two most significant units, like "3h12m"
+func formatDuration(d time.Duration) string {
+	if d > -time.Second && d < time.Second {
+		return d.String()
+	}
+
+	// The magnitude is unsigned, as negating math.MinInt64 overflows
+	sign, magnitude := "", uint64(d)
+	if d < 0 {
+		sign, magnitude = "-", -magnitude
+	}
+
+	text := ""
+	units := 0
+	for _, unit := range durationUnits {
+		if count := magnitude / unit.duration; count > 0 || units > 0 {
+			if count > 0 {
+				text += fmt.Sprintf("%d%s", count, unit.suffix)
+			}
+			magnitude -= count * unit.duration
+			if units++; units == 2 {
+				break
+			}
+		}
+	}
+	return sign + text
+}
+
+// formatSeconds renders a number of seconds which exceeds the range of time.Duration
+// using days and hours, like "213503982334601d"
+func formatSeconds(seconds float64) string {
+	if math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+		return strconv.FormatFloat(seconds, 'f', -1, 64) + "s"
+	} else if seconds < 0 {
+		return "-" + formatSeconds(-seconds)
+	}
+
+	days := math.Floor(seconds / 86400)
+	text := strconv.FormatFloat(days, 'f', 0, 64) + "d"
+	// The remainder is imprecise for large numbers, so it is only rendered if it makes sense
+	if hours := math.Floor((seconds - days*86400) / 3600); hours > 0 && hours < 24 {
+		text += strconv.FormatFloat(hours, 'f', 0, 64) + "h"
+	}
+	return text
+}
@@ -0,0 +1,53 @@
+package human
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		format   string
+		expected string
+	}{
+		{512, FormatBytes, "512 B"},
+		{uint64(1536), FormatBytes, "1.5 KiB"},
+		{int64(3 << 30), FormatBytes, "3 GiB"},
+		{-2048, FormatBytes, "-2 KiB"},
+		{999, FormatSI, "999"},
+		{12400, FormatSI, "12.4k"},
+		{float32(3.2e6), FormatSI, "3.2M"},
+		{87.5, FormatPercent, "87.5 %"},
+		{uint8(100), FormatPercent, "100 %"},
+		{11520, FormatDuration, "3h12m"},
+		{11525, FormatDuration, "3h12m"},
+		{10805, FormatDuration, "3h"},
+		{183600, FormatDuration, "2d3h"},
+		{42.0, FormatDuration, "42s"},
+		{0.25, FormatDuration, "250ms"},
+		{-90, FormatDuration, "-1m30s"},
+		{time.Minute + 30*time.Second, FormatDuration, "1m30s"},
+		{time.Duration(math.MinInt64), FormatDuration, "-106751d23h"},
+		{time.Duration(math.MaxInt64), FormatDuration, "106751d23h"},
+		{-250 * time.Millisecond, FormatDuration, "-250ms"},
+		{uint64(math.MaxUint64), FormatDuration, "213503982334601d6h"},
+		{int64(math.MinInt64), FormatDuration, "-106751991167300d15h"},
+		{math.Inf(1), FormatDuration, "+Infs"},
+	}
+
+	for _, test := range tests {
+		text, ok := formatNumber(reflect.ValueOf(test.value), test.format)
+		require.True(t, ok, "%v with format %s", test.value, test.format)
+		require.EqualValues(t, test.expected, text, "%v with format %s", test.value, test.format)
+	}
+
+	_, ok := formatNumber(reflect.ValueOf("1024"), FormatBytes)
+	require.False(t, ok)
+	_, ok = formatNumber(reflect.ValueOf(1024), "")
+	require.False(t, ok)
+}
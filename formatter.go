@@ -0,0 +1,72 @@
+package human
+
+import "reflect"
+
+// TypeFormatter formats a value as single line of text.
+// TypeFormatters allow for overriding how types which cannot be extended with
+// methods, like types of third-party packages, are rendered.
+type TypeFormatter func(reflect.Value) (string, error)
+
+// interfaceFormatter holds a TypeFormatter which applies to all types implementing an interface
+type interfaceFormatter struct {
+	iface     reflect.Type
+	formatter TypeFormatter
+}
+
+// lookupFormatter returns the TypeFormatter that applies to the given value.
+// Formatters registered for the value's exact type take precedence over formatters
+// registered for interfaces, which are checked in order of registration.
+// Pointers and interfaces are dereferenced until a formatter applies. Formatters are never
+// applied to nil pointers and interfaces.
+func (e *Encoder) lookupFormatter(v reflect.Value) (formatter TypeFormatter, value reflect.Value, ok bool) {
+	if len(e.typeFormatters) == 0 && len(e.interfaceFormatters) == 0 {
+		return
+	}
+
+	for value = v; isFormattable(value); value = value.Elem() {
+		if formatter, ok = e.typeFormatters[value.Type()]; ok {
+			return
+		}
+		if value.Kind() != reflect.Ptr && value.Kind() != reflect.Interface {
+			break
+		}
+	}
+
+	for value = v; isFormattable(value); value = value.Elem() {
+		for _, f := range e.interfaceFormatters {
+			if value.Type().Implements(f.iface) {
+				return f.formatter, value, true
+			}
+		}
+		if value.Kind() != reflect.Ptr && value.Kind() != reflect.Interface {
+			break
+		}
+	}
+	return
+}
+
+// isFormattable checks if a TypeFormatter may be applied to the given value
+func isFormattable(v reflect.Value) bool {
+	if !v.IsValid() {
+		return false
+	}
+	return (v.Kind() != reflect.Ptr && v.Kind() != reflect.Interface) || !v.IsNil()
+}
+
+// hasFormatter checks if a TypeFormatter applies to values of the given type,
+// dereferencing pointer types like lookupFormatter does
+func (e *Encoder) hasFormatter(t reflect.Type) bool {
+	for ; ; t = t.Elem() {
+		if _, ok := e.typeFormatters[t]; ok {
+			return true
+		}
+		for _, f := range e.interfaceFormatters {
+			if t.Implements(f.iface) {
+				return true
+			}
+		}
+		if t.Kind() != reflect.Ptr {
+			return false
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package human
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// formatterTestID represents a third-party type, which implements fmt.Stringer
+type formatterTestID [2]byte
+
+func (id formatterTestID) String() string {
+	return "stringer"
+}
+
+var errFormatterTest = errors.New("formatter test error")
+
+func formatID(v reflect.Value) (string, error) {
+	id := v.Interface().(formatterTestID)
+	return fmt.Sprintf("%x-%x", id[0], id[1]), nil
+}
+
+func TestEncoder_Encode_TypeFormatter(t *testing.T) {
+	outputBuffer := bytes.NewBufferString("")
+	enc, err := NewEncoder(outputBuffer,
+		OptionTypeFormatter(reflect.TypeOf(formatterTestID{}), formatID),
+		OptionInterfaceFormatter(reflect.TypeOf((*fmt.Stringer)(nil)).Elem(), func(v reflect.Value) (string, error) {
+			return strings.ToUpper(v.Interface().(fmt.Stringer).String()), nil
+		}))
+	require.NoError(t, err)
+
+	ip := net.ParseIP("10.0.0.1")
+	s := struct {
+		ID    formatterTestID
+		IDPtr *formatterTestID
+		IP    *net.IP
+		Any   []interface{}
+		ByID  map[formatterTestID]int
+	}{
+		ID:    formatterTestID{1, 2},
+		IDPtr: &formatterTestID{3, 4},
+		IP:    &ip,
+		Any:   []interface{}{formatterTestID{5, 6}, nil},
+		ByID:  map[formatterTestID]int{{7, 8}: 1},
+	}
+
+	expectedOutput := "\n" +
+		"ID: 1-2\n" +
+		"IDPtr: 3-4\n" +
+		"IP: 10.0.0.1\n" +
+		"Any:\n" +
+		"  * 5-6\n" +
+		"  * <nil>\n" +
+		"ByID:\n" +
+		"  * 7-8: 1\n"
+
+	require.NoError(t, enc.Encode(s))
+	require.EqualValues(t, expectedOutput, outputBuffer.String())
+}
+
+func TestEncoder_Encode_TypeFormatterError(t *testing.T) {
+	outputBuffer := bytes.NewBufferString("")
+	enc, err := NewEncoder(outputBuffer,
+		OptionTypeFormatter(reflect.TypeOf(formatterTestID{}), func(reflect.Value) (string, error) {
+			return "", errFormatterTest
+		}))
+	require.NoError(t, err)
+
+	s := struct {
+		ID formatterTestID
+	}{}
+
+	err = enc.Encode(s)
+	require.Error(t, err)
+	require.EqualValues(t, errFormatterTest, findEncodeError(t, err, "ID").Err)
+}
@@ -0,0 +1,324 @@
+package human
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// KeyLess defines the function type used for sorting the keys of maps.
+// It reports whether the key a is rendered before the key b.
+type KeyLess func(a, b reflect.Value) bool
+
+// mapEntry is the key of a map entry, along with the text it is rendered with
+type mapEntry struct {
+	key  reflect.Value
+	text string
+	// err holds the error returned when formatting the key, in which case text holds a placeholder
+	err error
+}
+
+// mapEntrySorter sorts map entries using a KeyLess function or, if none is set,
+// using lessMapEntry
+type mapEntrySorter struct {
+	entries []mapEntry
+	less    KeyLess
+}
+
+func (s *mapEntrySorter) Len() int {
+	return len(s.entries)
+}
+
+func (s *mapEntrySorter) Swap(i, j int) {
+	s.entries[i], s.entries[j] = s.entries[j], s.entries[i]
+}
+
+func (s *mapEntrySorter) Less(i, j int) bool {
+	if s.less != nil {
+		return s.less(s.entries[i].key, s.entries[j].key)
+	}
+	return lessMapEntry(s.entries[i], s.entries[j])
+}
+
+// mapEntries returns the entries of a map in the order they are rendered in.
+// Every key results in an entry, even if several keys are rendered with the same text.
+// Keys which cannot be formatted are rendered as placeholder.
+func (e *Encoder) mapEntries(v reflect.Value) []mapEntry {
+	keys := v.MapKeys()
+	entries := make([]mapEntry, len(keys))
+	for i, key := range keys {
+		text, err := e.formatKey(key)
+		if err != nil {
+			text = formatErrorPlaceholder(err)
+		}
+		entries[i] = mapEntry{
+			key:  key,
+			text: text,
+			err:  err,
+		}
+	}
+
+	sort.Stable(&mapEntrySorter{
+		entries: entries,
+		less:    e.keyLess,
+	})
+	return entries
+}
+
+// lessMapEntry defines the default order of map keys.
+// Numeric keys are sorted by their value and precede all other keys, which are sorted by
+// their text. Keys with the same text are sorted by their type and Go representation,
+// so the order does not depend on the order of iteration over the map.
+func lessMapEntry(a, b mapEntry) bool {
+	keyA, keyB := concreteKey(a.key), concreteKey(b.key)
+
+	numericA, numericB := isNumeric(keyA), isNumeric(keyB)
+	if numericA && numericB {
+		if c := compareNumbers(keyA, keyB); c != 0 {
+			return c < 0
+		}
+	} else if numericA != numericB {
+		return numericA
+	}
+
+	if a.text != b.text {
+		return a.text < b.text
+	}
+
+	typeA, typeB := keyTypeName(keyA), keyTypeName(keyB)
+	if typeA != typeB {
+		return typeA < typeB
+	}
+	return fmt.Sprintf("%#v", a.key.Interface()) < fmt.Sprintf("%#v", b.key.Interface())
+}
+
+// concreteKey returns the value stored in an interface key.
+// The returned value is invalid for nil interfaces.
+func concreteKey(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Interface {
+		return v.Elem()
+	}
+	return v
+}
+
+// keyTypeName returns the name of the key's type, which is empty for invalid values
+func keyTypeName(v reflect.Value) string {
+	if !v.IsValid() {
+		return ""
+	}
+	return v.Type().String()
+}
+
+// isNumeric checks if the value is an integer or a floating-point number
+func isNumeric(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// isSigned checks if the numeric value is a signed integer
+func isSigned(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+// isFloat checks if the numeric value is a floating-point number
+func isFloat(v reflect.Value) bool {
+	return v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64
+}
+
+// compareNumbers compares two numeric values and returns -1, 0 or 1 if a is less than,
+// equal to or greater than b. Integers of different signedness are compared exactly.
+func compareNumbers(a, b reflect.Value) int {
+	switch {
+	case isFloat(a) || isFloat(b):
+		return compareFloats(toFloat(a), toFloat(b))
+	case isSigned(a) && isSigned(b):
+		return compareInts(a.Int(), b.Int())
+	case !isSigned(a) && !isSigned(b):
+		return compareUints(a.Uint(), b.Uint())
+	case isSigned(a):
+		if a.Int() < 0 {
+			return -1
+		}
+		return compareUints(uint64(a.Int()), b.Uint())
+	default:
+		if b.Int() < 0 {
+			return 1
+		}
+		return compareUints(a.Uint(), uint64(b.Int()))
+	}
+}
+
+// toFloat converts a numeric value to float64
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case isFloat(v):
+		return v.Float()
+	case isSigned(v):
+		return float64(v.Int())
+	default:
+		return float64(v.Uint())
+	}
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	// Equal values and NaN are considered equal
+	return 0
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareUints(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// inlineFieldSeparator separates the fields of structs which are rendered inline
+const inlineFieldSeparator = ", "
+
+// formatKey returns the text a map key is rendered with
+func (e *Encoder) formatKey(v reflect.Value) (string, error) {
+	if formatter, formatterValue, ok := e.lookupFormatter(v); ok {
+		return formatter(formatterValue)
+	}
+
+	v = concreteKey(v)
+	if !v.IsValid() {
+		return fmt.Sprint(nil), nil
+	}
+
+	i := v.Interface()
+	if marshaler, ok := i.(KeyMarshaler); ok {
+		return marshaler.MarshalHumanKey()
+	} else if marshaler, ok := i.(encoding.TextMarshaler); ok {
+		// Keys are rendered the same way as values implementing encoding.TextMarshaler
+		text, err := marshaler.MarshalText()
+		return string(text), err
+	} else if isInlineStruct(v) {
+		return e.formatInlineStruct(v)
+	}
+	return fmt.Sprint(i), nil
+}
+
+// isInlineStruct checks if the value is a struct which is rendered inline, as it does not
+// provide a textual representation of its own
+func isInlineStruct(v reflect.Value) bool {
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+	switch v.Interface().(type) {
+	case KeyMarshaler, encoding.TextMarshaler, fmt.Stringer, error:
+		return false
+	}
+	return true
+}
+
+// formatInlineStruct renders the non-empty fields of a struct as a single line,
+// like "Name=Person1, Property2=4.5"
+func (e *Encoder) formatInlineStruct(v reflect.Value) (string, error) {
+	fields, err := e.inlineFields(v, nil, nil)
+	if err != nil {
+		return "", err
+	}
+
+	keys := make([]orderKey, len(fields))
+	for i, field := range fields {
+		keys[i] = orderKey{
+			goName: field.info.name,
+			name:   e.fieldName(field.info.tag),
+			tag:    field.info.tag,
+		}
+	}
+
+	parts := make([]string, 0, len(fields))
+	for _, i := range e.fieldOrder(v, keys) {
+		fieldValue := fields[i].value
+		tag := fields[i].info.tag
+
+		fieldInterface := fieldValue.Interface()
+		if !isFormattable(fieldValue) || fieldInterface == nil || (tag.omitEmpty && IsNilOrEmpty(fieldInterface, fieldValue)) {
+			continue
+		}
+
+		var text string
+		if _, _, hasFormatter := e.lookupFormatter(fieldValue); !hasFormatter && !tag.redact && isInlineStruct(fieldValue) {
+			// Nested structs are enclosed in braces
+			text, err = e.formatInlineStruct(fieldValue)
+			text = "{" + text + "}"
+		} else {
+			text, err = e.formatCell(fieldValue, tag)
+		}
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, keys[i].name+"="+text)
+	}
+	return strings.Join(parts, inlineFieldSeparator), nil
+}
+
+// inlineFields appends the fields of a struct which is rendered inline to fields, including
+// the fields of anonymous structs. Anonymous struct pointers which have already been visited
+// are skipped, as they form a cycle.
+func (e *Encoder) inlineFields(v reflect.Value, fields []structField, visited map[uintptr]bool) ([]structField, error) {
+	for _, field := range e.structInfo(v.Type()).fields {
+		fieldValue := v.Field(field.index)
+
+		if field.anonymous {
+			if fieldValue.Kind() == reflect.Ptr {
+				if fieldValue.IsNil() || visited[fieldValue.Pointer()] {
+					continue
+				}
+				if visited == nil {
+					visited = make(map[uintptr]bool)
+				}
+				visited[fieldValue.Pointer()] = true
+				fieldValue = fieldValue.Elem()
+			}
+
+			var err error
+			if fields, err = e.inlineFields(fieldValue, fields, visited); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if field.tagErr != nil {
+			return nil, field.tagErr
+		}
+
+		fields = append(fields, structField{
+			value: fieldValue,
+			info:  field,
+		})
+	}
+	return fields, nil
+}
@@ -0,0 +1,178 @@
+package human
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareNumbers(t *testing.T) {
+	for _, testCase := range []struct {
+		a, b     interface{}
+		expected int
+	}{
+		{9, 10, -1},
+		{10, 9, 1},
+		{-1, uint(0), -1},
+		{uint(0), -1, 1},
+		{uint64(1 << 63), int64(1<<63 - 1), 1},
+		{1, 1.5, -1},
+		{2.0, 2, 0},
+		{uint8(3), int16(3), 0},
+	} {
+		require.EqualValues(t, testCase.expected, compareNumbers(reflect.ValueOf(testCase.a), reflect.ValueOf(testCase.b)),
+			"%v <=> %v", testCase.a, testCase.b)
+	}
+}
+
+func TestEncoder_Encode_MapKeys(t *testing.T) {
+	t.Run("Numeric", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer)
+		require.NoError(t, err)
+
+		m := map[int]string{
+			10: "ten",
+			9:  "nine",
+			-1: "minus one",
+			1:  "one",
+		}
+		require.NoError(t, enc.Encode(m))
+		require.EqualValues(t, "\n* -1: minus one\n* 1: one\n* 9: nine\n* 10: ten\n", outputBuffer.String())
+	})
+
+	t.Run("Collision", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer)
+		require.NoError(t, err)
+
+		m := map[interface{}]string{
+			"1":  "string",
+			1:    "int",
+			1.5:  "float",
+			"a":  "letter",
+			true: "bool",
+		}
+		require.NoError(t, enc.Encode(m))
+		require.EqualValues(t, "\n* 1: int\n* 1.5: float\n* 1: string\n* a: letter\n* true: bool\n", outputBuffer.String())
+	})
+
+	t.Run("EqualText", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer)
+		require.NoError(t, err)
+
+		type key struct {
+			Name string
+			id   int
+		}
+		m := map[key]int{
+			{Name: "b", id: 2}: 2,
+			{Name: "b", id: 1}: 1,
+			{Name: "a", id: 3}: 3,
+		}
+		for i := 0; i < 10; i++ {
+			outputBuffer.Reset()
+			require.NoError(t, enc.Encode(m))
+			require.EqualValues(t, "\n* Name=a: 3\n* Name=b: 1\n* Name=b: 2\n", outputBuffer.String())
+		}
+	})
+
+	t.Run("KeyLess", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer, OptionKeyLess(func(a, b reflect.Value) bool {
+			return a.Int() > b.Int()
+		}))
+		require.NoError(t, err)
+
+		m := map[int]string{
+			1:  "one",
+			2:  "two",
+			10: "ten",
+		}
+		require.NoError(t, enc.Encode(m))
+		require.EqualValues(t, "\n* 10: ten\n* 2: two\n* 1: one\n", outputBuffer.String())
+	})
+}
+
+type keyMarshalerTest struct {
+	Region string
+	Zone   int
+}
+
+func (k keyMarshalerTest) MarshalHumanKey() (string, error) {
+	return fmt.Sprintf("%s-%d", k.Region, k.Zone), nil
+}
+
+type stringerKeyTest struct {
+	Name string
+}
+
+func (k stringerKeyTest) String() string {
+	return "stringer " + k.Name
+}
+
+type textMarshalerKeyTest struct {
+	A int
+}
+
+func (k textMarshalerKeyTest) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("tm%d", k.A)), nil
+}
+
+type inlineKeyEmbeddedTest struct {
+	Region string `human:"region"`
+}
+
+type inlineKeyTest struct {
+	inlineKeyEmbeddedTest
+	Name     string             `human:"Host name,order=1"`
+	Ignored  string             `human:"-"`
+	Comment  string             `human:",omitempty"`
+	Size     uint64             `human:",format=bytes"`
+	Location struct{ X, Y int } `human:"location"`
+	Secret   string             `human:",redact"`
+}
+
+func TestEncoder_Encode_StructMapKeys(t *testing.T) {
+	t.Run("Inline", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer)
+		require.NoError(t, err)
+
+		key := inlineKeyTest{
+			inlineKeyEmbeddedTest: inlineKeyEmbeddedTest{
+				Region: "eu",
+			},
+			Name:    "host",
+			Ignored: "ignored",
+			Size:    2048,
+			Secret:  "secret",
+		}
+		key.Location.X = 1
+		require.NoError(t, enc.Encode(map[inlineKeyTest]int{key: 1}))
+		require.EqualValues(t, "\n* Host name=host, region=eu, Size=2 KiB, location={X=1, Y=0}, Secret=********: 1\n", outputBuffer.String())
+	})
+
+	t.Run("KeyMarshaler", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer)
+		require.NoError(t, err)
+
+		m := map[interface{}]int{
+			keyMarshalerTest{Region: "eu", Zone: 1}: 1,
+			stringerKeyTest{Name: "test"}:           2,
+			textMarshalerKeyTest{A: 1}:              3,
+		}
+		require.NoError(t, enc.Encode(m))
+		require.EqualValues(t, "\n* eu-1: 1\n* stringer test: 2\n* tm1: 3\n", outputBuffer.String())
+
+		// Keys are rendered the same way as values
+		outputBuffer.Reset()
+		require.NoError(t, enc.Encode(map[textMarshalerKeyTest]textMarshalerKeyTest{{A: 1}: {A: 2}}))
+		require.EqualValues(t, "\n* tm1: tm2\n", outputBuffer.String())
+	})
+}
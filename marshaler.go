@@ -0,0 +1,96 @@
+package human
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Marshaler is the interface implemented by types that can render themselves
+// as human readable text.
+//
+// In contrast to encoding.TextMarshaler and fmt.Stringer, which are limited to
+// a single line, MarshalHuman receives a *Writer that is aware of the current
+// indentation level and list symbol. This allows for multi-line, nested output
+// that fits into the surrounding document.
+type Marshaler interface {
+	MarshalHuman(w *Writer) error
+}
+
+// KeyMarshaler is the interface implemented by types that render themselves as a single line
+// when used as map key.
+//
+// Map keys which do not implement KeyMarshaler are rendered using encoding.TextMarshaler,
+// like values are, or fmt.Sprint otherwise. Structs implementing none of encoding.TextMarshaler,
+// fmt.Stringer and error are rendered inline, like "Name=Person1, Property2=4.5", honoring the
+// tags of their fields.
+type KeyMarshaler interface {
+	MarshalHumanKey() (string, error)
+}
+
+// Writer is passed to Marshaler implementations and writes lines at the
+// nesting level of the value being marshaled.
+type Writer struct {
+	state       *encodeState
+	indentLevel int
+	inList      bool
+	// items counts the list items written so far, which are indexed in the path of errors
+	items int
+}
+
+// IndentLevel returns the nesting level the Writer writes at.
+func (w *Writer) IndentLevel() int {
+	return w.indentLevel
+}
+
+// Indent returns the whitespace prefix used for lines at the Writer's
+// nesting level.
+func (w *Writer) Indent() string {
+	return strings.Repeat(" ", int(w.state.indent)*w.indentLevel)
+}
+
+// ListSymbol returns the list symbol used for list items at the Writer's
+// nesting level.
+func (w *Writer) ListSymbol() string {
+	return w.state.listSymbol(w.indentLevel)
+}
+
+// Line writes a single line of text.
+func (w *Writer) Line(text string) {
+	fmt.Fprintln(w.state.stream, w.prefix()+text)
+}
+
+// Field writes a "name: value" line, encoding v the same way a struct
+// field would be encoded.
+// If v cannot be encoded, the returned error is an EncodeErrors.
+func (w *Writer) Field(name string, v interface{}) error {
+	fmt.Fprint(w.state.stream, w.prefix()+w.state.colorize(w.state.theme.Key, name)+":")
+	errCount := len(w.state.errs)
+	w.state.path = append(w.state.path, name)
+	w.state.encodeValue(v, reflect.ValueOf(v), w.indentLevel, false, tagInfo{})
+	w.state.path = w.state.path[:len(w.state.path)-1]
+	return w.state.errorsSince(errCount)
+}
+
+// Item writes a list item, encoding v the same way a slice element would be
+// encoded.
+// If v cannot be encoded, the returned error is an EncodeErrors.
+func (w *Writer) Item(v interface{}) error {
+	fmt.Fprint(w.state.stream, w.prefix()+w.state.colorize(w.state.theme.ListSymbol, w.ListSymbol()))
+	errCount := len(w.state.errs)
+	w.state.path = append(w.state.path, fmt.Sprintf("[%d]", w.items))
+	w.items++
+	w.state.encodeValue(v, reflect.ValueOf(v), w.indentLevel, true, tagInfo{})
+	w.state.path = w.state.path[:len(w.state.path)-1]
+	return w.state.errorsSince(errCount)
+}
+
+// prefix returns the prefix for the next line. If the marshaled value is
+// a list item, the first line is placed right after the list symbol.
+func (w *Writer) prefix() string {
+	if w.inList {
+		w.inList = false
+		return " "
+	}
+	return w.Indent()
+}
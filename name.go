@@ -0,0 +1,81 @@
+package human
+
+import (
+	"strings"
+	"unicode"
+)
+
+// FieldNameTransform defines the function type used for transforming the Go name of a
+// struct field into the name that is rendered
+type FieldNameTransform func(name string) string
+
+// FieldNameTitle transforms field names into space-separated words, like "CreatedAt" into
+// "Created At" and "IPv4Address" into "IPv4 Address"
+func FieldNameTitle(name string) string {
+	return strings.Join(splitFieldName(name), " ")
+}
+
+// FieldNameSnakeCase transforms field names into lower-case words separated by underscores,
+// like "CreatedAt" into "created_at"
+func FieldNameSnakeCase(name string) string {
+	return strings.ToLower(strings.Join(splitFieldName(name), "_"))
+}
+
+// FieldNameKebabCase transforms field names into lower-case words separated by dashes,
+// like "CreatedAt" into "created-at"
+func FieldNameKebabCase(name string) string {
+	return strings.ToLower(strings.Join(splitFieldName(name), "-"))
+}
+
+// splitFieldName splits a Go identifier into its words.
+// A word starts at each upper-case letter following a lower-case letter or a digit.
+// Within a run of upper-case letters, a word starts at the last upper-case letter if it is
+// followed by at least two lower-case letters, so "HTTPServer" is split into "HTTP" and "Server",
+// while "IPv4" and "CPUs" are kept. Underscores separate words and are dropped.
+func splitFieldName(name string) (words []string) {
+	runes := []rune(name)
+	start := 0
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '_' {
+			if i > start {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+			continue
+		}
+
+		if i == start || !unicode.IsUpper(runes[i]) {
+			continue
+		}
+
+		previous := runes[i-1]
+		if unicode.IsLower(previous) || unicode.IsDigit(previous) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		} else if unicode.IsUpper(previous) && lowerRunLength(runes[i+1:]) >= 2 {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return
+}
+
+// lowerRunLength returns the number of lower-case letters at the start of the given runes
+func lowerRunLength(runes []rune) (n int) {
+	for n < len(runes) && unicode.IsLower(runes[n]) {
+		n++
+	}
+	return
+}
+
+// fieldName returns the name a struct field is rendered with.
+// Names that were not specified by the tag are passed through the field name transform.
+func (e *Encoder) fieldName(tag tagInfo) string {
+	if tag.implicitName && e.fieldNameTransform != nil {
+		return e.fieldNameTransform(tag.name)
+	}
+	return tag.name
+}
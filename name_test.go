@@ -0,0 +1,76 @@
+package human
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitFieldName(t *testing.T) {
+	for name, expected := range map[string][]string{
+		"Name":        {"Name"},
+		"CreatedAt":   {"Created", "At"},
+		"IPv4Address": {"IPv4", "Address"},
+		"HTTPServer":  {"HTTP", "Server"},
+		"UserID":      {"User", "ID"},
+		"ID":          {"ID"},
+		"CPUs":        {"CPUs"},
+		"Top10Items":  {"Top10", "Items"},
+		"Created_At":  {"Created", "At"},
+		"Größe":       {"Größe"},
+	} {
+		require.EqualValues(t, expected, splitFieldName(name), name)
+	}
+}
+
+func TestFieldNameTransforms(t *testing.T) {
+	require.EqualValues(t, "Created At", FieldNameTitle("CreatedAt"))
+	require.EqualValues(t, "IPv4 Address", FieldNameTitle("IPv4Address"))
+	require.EqualValues(t, "created_at", FieldNameSnakeCase("CreatedAt"))
+	require.EqualValues(t, "ipv4_address", FieldNameSnakeCase("IPv4Address"))
+	require.EqualValues(t, "created-at", FieldNameKebabCase("CreatedAt"))
+	require.EqualValues(t, "http-server", FieldNameKebabCase("HTTPServer"))
+}
+
+func TestEncoder_Encode_FieldNameTransform(t *testing.T) {
+	s := struct {
+		CreatedAt   string
+		IPv4Address string `human:"address"`
+		DiskSize    int    `human:",omitempty"`
+	}{
+		CreatedAt:   "today",
+		IPv4Address: "192.0.2.1",
+		DiskSize:    20,
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer)
+		require.NoError(t, err)
+
+		require.NoError(t, enc.Encode(s))
+		require.EqualValues(t, "\nCreatedAt: today\naddress: 192.0.2.1\nDiskSize: 20\n", outputBuffer.String())
+	})
+
+	t.Run("Title", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer, OptionFieldNameTransform(FieldNameTitle))
+		require.NoError(t, err)
+
+		require.NoError(t, enc.Encode(s))
+		require.EqualValues(t, "\nCreated At: today\naddress: 192.0.2.1\nDisk Size: 20\n", outputBuffer.String())
+	})
+
+	t.Run("Table", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer, OptionFieldNameTransform(FieldNameSnakeCase), OptionTables(true))
+		require.NoError(t, err)
+
+		type row struct {
+			DiskSize int
+		}
+		require.NoError(t, enc.Encode([]row{{DiskSize: 1}}))
+		require.EqualValues(t, "\ndisk_size\n1\n", outputBuffer.String())
+	})
+}
@@ -1,5 +1,10 @@
 package human
 
+import (
+	"reflect"
+	"time"
+)
+
 // DefaultTagName defines the default tag name
 const DefaultTagName = "human"
 
@@ -9,6 +14,36 @@ const DefaultListSymbol = "*"
 // DefaultIndent defines the default indentation
 const DefaultIndent = 2
 
+// CycleHandling defines how the Encoder handles values that reference themselves
+type CycleHandling int
+
+const (
+	// CycleHandlingMarker renders a "<cycle: path>" marker in place of the repeated value
+	CycleHandlingMarker CycleHandling = iota
+	// CycleHandlingError skips the repeated value and records a *CycleError, which is returned
+	// as cause of an *EncodeError within the EncodeErrors once encoding has finished
+	CycleHandlingError
+)
+
+// DefaultCycleHandling defines the default cycle handling
+const DefaultCycleHandling = CycleHandlingMarker
+
+// MultilineMode defines how the Encoder renders strings that span multiple lines
+type MultilineMode int
+
+const (
+	// MultilineBlock renders multi-line strings as a block, which is indented one level
+	// deeper than the current value
+	MultilineBlock MultilineMode = iota
+	// MultilineQuoted renders multi-line strings as a single quoted line with escaped newlines
+	MultilineQuoted
+	// MultilineTruncate renders only the first line of multi-line strings
+	MultilineTruncate
+)
+
+// DefaultMultilineMode defines the default multi-line mode
+const DefaultMultilineMode = MultilineBlock
+
 // Option defines the function type of Encoder options
 type Option func(*Encoder) error
 
@@ -16,6 +51,12 @@ var defaultOptions = []Option{
 	OptionTagName(DefaultTagName),
 	OptionListSymbols(DefaultListSymbol),
 	OptionIndent(DefaultIndent),
+	OptionCycleHandling(DefaultCycleHandling),
+	OptionMultilineMode(DefaultMultilineMode),
+	OptionColorMode(DefaultColorMode),
+	OptionTimeFormat(DefaultTimeFormat),
+	OptionClock(time.Now),
+	OptionRedactor(RedactFull),
 }
 
 // OptionTagName specifies the tag name
@@ -29,6 +70,27 @@ func OptionTagName(tagName string) Option {
 	}
 }
 
+// OptionTagNames specifies a chain of tag names, like "human", "json" and "yaml".
+// For each field, the first tag of the chain that is present is used.
+// The first tag name replaces the tag name specified by OptionTagName and supports the full tag syntax.
+// All following tags are parsed following the conventions of encoding/json, so only the name,
+// the omitempty option and the "-" value are respected, while all other options are ignored.
+func OptionTagNames(tagNames ...string) Option {
+	return func(e *Encoder) error {
+		if len(tagNames) == 0 {
+			return ErrInvalidTagName
+		}
+		for _, tagName := range tagNames {
+			if tagName == "" {
+				return ErrInvalidTagName
+			}
+		}
+		e.tagName = tagNames[0]
+		e.fallbackTagNames = tagNames[1:]
+		return nil
+	}
+}
+
 // OptionListSymbols specifies the list symbols
 func OptionListSymbols(listSymbols ...string) Option {
 	return func(e *Encoder) error {
@@ -47,3 +109,217 @@ func OptionIndent(indent uint) Option {
 		return nil
 	}
 }
+
+// OptionCycleHandling specifies how values that reference themselves are handled
+func OptionCycleHandling(cycleHandling CycleHandling) Option {
+	return func(e *Encoder) error {
+		if cycleHandling != CycleHandlingMarker && cycleHandling != CycleHandlingError {
+			return ErrInvalidCycleHandling
+		}
+		e.cycleHandling = cycleHandling
+		return nil
+	}
+}
+
+// OptionMaxDepth specifies the maximum depth up to which structs, slices and maps are expanded.
+// Values nested deeper are rendered as a one-line summary, like "<map: 3 keys>".
+// A maximum depth of 0 disables the limit.
+func OptionMaxDepth(maxDepth uint) Option {
+	return func(e *Encoder) error {
+		e.maxDepth = maxDepth
+		return nil
+	}
+}
+
+// OptionMultilineMode specifies how strings that span multiple lines are rendered
+func OptionMultilineMode(multilineMode MultilineMode) Option {
+	return func(e *Encoder) error {
+		if multilineMode != MultilineBlock && multilineMode != MultilineQuoted && multilineMode != MultilineTruncate {
+			return ErrInvalidMultilineMode
+		}
+		e.multilineMode = multilineMode
+		return nil
+	}
+}
+
+// OptionTables specifies if slices of structs are rendered as tables.
+// Tables can also be enabled for individual fields using the "table" tag option.
+func OptionTables(tables bool) Option {
+	return func(e *Encoder) error {
+		e.tables = tables
+		return nil
+	}
+}
+
+// OptionTheme specifies the theme used for coloring the output.
+// Whether the theme is applied depends on the color mode, see OptionColorMode.
+func OptionTheme(theme Theme) Option {
+	return func(e *Encoder) error {
+		e.theme = theme
+		return nil
+	}
+}
+
+// OptionColorMode specifies when the theme is applied
+func OptionColorMode(colorMode ColorMode) Option {
+	return func(e *Encoder) error {
+		if colorMode != ColorAuto && colorMode != ColorAlways && colorMode != ColorNever {
+			return ErrInvalidColorMode
+		}
+		e.colorMode = colorMode
+		return nil
+	}
+}
+
+// OptionWidth specifies the width at which textual values are soft-wrapped.
+// Continuation lines are aligned with the start of the value.
+// A width of 0 disables wrapping. TerminalWidth can be used for determining the width of a terminal.
+func OptionWidth(width uint) Option {
+	return func(e *Encoder) error {
+		e.width = width
+		return nil
+	}
+}
+
+// OptionStreaming enables streaming of the output. Instead of holding back the complete
+// output until encoding has finished, complete lines are written as soon as more than
+// bufferSize bytes are buffered.
+// In contrast to the default mode, output may be written partially if an error occurs.
+// A buffer size of 0 disables streaming.
+func OptionStreaming(bufferSize uint) Option {
+	return func(e *Encoder) error {
+		e.streamLimit = bufferSize
+		return nil
+	}
+}
+
+// OptionPartialOutput specifies if the output is written even if some values cannot be encoded.
+// Values that cannot be encoded are replaced by placeholders like "<error: marshal failed>",
+// while Encode still returns the aggregated error.
+func OptionPartialOutput(partialOutput bool) Option {
+	return func(e *Encoder) error {
+		e.partialOutput = partialOutput
+		return nil
+	}
+}
+
+// OptionTypeFormatter registers a TypeFormatter for the given type.
+// The formatter is used for values and map keys of this type, taking precedence over
+// Marshaler, encoding.TextMarshaler and fmt.Stringer implementations.
+func OptionTypeFormatter(t reflect.Type, formatter TypeFormatter) Option {
+	return func(e *Encoder) error {
+		if t == nil || formatter == nil {
+			return ErrInvalidTypeFormatter
+		}
+		if e.typeFormatters == nil {
+			e.typeFormatters = make(map[reflect.Type]TypeFormatter)
+		}
+		e.typeFormatters[t] = formatter
+		return nil
+	}
+}
+
+// OptionInterfaceFormatter registers a TypeFormatter for all types implementing the given
+// interface type. Formatters registered using OptionTypeFormatter take precedence,
+// formatters for interface types are checked in order of registration.
+func OptionInterfaceFormatter(iface reflect.Type, formatter TypeFormatter) Option {
+	return func(e *Encoder) error {
+		if iface == nil || iface.Kind() != reflect.Interface || formatter == nil {
+			return ErrInvalidTypeFormatter
+		}
+		e.interfaceFormatters = append(e.interfaceFormatters, interfaceFormatter{
+			iface:     iface,
+			formatter: formatter,
+		})
+		return nil
+	}
+}
+
+// OptionFieldNameTransform specifies a function which transforms the Go names of struct fields
+// into the rendered names, like FieldNameTitle, FieldNameSnakeCase or FieldNameKebabCase.
+// The transform is only applied to fields whose tag does not specify a name.
+func OptionFieldNameTransform(transform FieldNameTransform) Option {
+	return func(e *Encoder) error {
+		if transform == nil {
+			return ErrInvalidFieldNameTransform
+		}
+		e.fieldNameTransform = transform
+		return nil
+	}
+}
+
+// OptionRedactor specifies the Redactor used for rendering fields with the "redact" or "secret"
+// tag option, like RedactFull or RedactPartial
+func OptionRedactor(redactor Redactor) Option {
+	return func(e *Encoder) error {
+		if redactor == nil {
+			return ErrInvalidRedactor
+		}
+		e.redactor = redactor
+		return nil
+	}
+}
+
+// OptionSortFields specifies if the fields of structs are sorted alphabetically by name.
+// Fields with the "order" tag option and fields listed by FieldOrderer implementations
+// still take precedence.
+func OptionSortFields(sortFields bool) Option {
+	return func(e *Encoder) error {
+		e.sortFields = sortFields
+		return nil
+	}
+}
+
+// OptionKeyLess specifies the function used for sorting the keys of maps.
+// By default, numeric keys are sorted by their value, followed by all other keys sorted by their text.
+func OptionKeyLess(less KeyLess) Option {
+	return func(e *Encoder) error {
+		if less == nil {
+			return ErrInvalidKeyLess
+		}
+		e.keyLess = less
+		return nil
+	}
+}
+
+// OptionTimeFormat specifies the layout used for rendering time.Time values, see time.Time.Format.
+// The layout can be overridden for individual fields using the "time" tag option.
+func OptionTimeFormat(layout string) Option {
+	return func(e *Encoder) error {
+		if layout == "" {
+			return ErrInvalidTimeFormat
+		}
+		e.timeFormat = layout
+		return nil
+	}
+}
+
+// OptionTimeLocation specifies the location time.Time values are converted to before
+// rendering them. If the location is nil, time.Time values are rendered in their own location.
+func OptionTimeLocation(loc *time.Location) Option {
+	return func(e *Encoder) error {
+		e.timeLocation = loc
+		return nil
+	}
+}
+
+// OptionRelativeTime specifies if time.Time values are rendered relative to the current time,
+// like "3 hours ago" or "in 2 days"
+func OptionRelativeTime(relativeTime bool) Option {
+	return func(e *Encoder) error {
+		e.relativeTime = relativeTime
+		return nil
+	}
+}
+
+// OptionClock specifies the function returning the current time, which is used for
+// rendering relative times
+func OptionClock(clock func() time.Time) Option {
+	return func(e *Encoder) error {
+		if clock == nil {
+			return ErrInvalidClock
+		}
+		e.clock = clock
+		return nil
+	}
+}
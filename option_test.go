@@ -1,7 +1,9 @@
 package human
 
 import (
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -16,6 +18,23 @@ func TestOptionTagName(t *testing.T) {
 	require.EqualValues(t, "test", enc.tagName)
 }
 
+func TestOptionTagNames(t *testing.T) {
+
+	enc := &Encoder{}
+
+	opt := OptionTagNames("test", "json", "yaml")
+
+	require.NoError(t, opt(enc))
+	require.EqualValues(t, "test", enc.tagName)
+	require.EqualValues(t, []string{"json", "yaml"}, enc.fallbackTagNames)
+
+	opt = OptionTagNames()
+	require.EqualError(t, opt(enc), ErrInvalidTagName.Error())
+
+	opt = OptionTagNames("test", "")
+	require.EqualError(t, opt(enc), ErrInvalidTagName.Error())
+}
+
 func TestOptionListSymbols(t *testing.T) {
 
 	enc := &Encoder{}
@@ -35,3 +54,229 @@ func TestOptionIndent(t *testing.T) {
 	require.NoError(t, opt(enc))
 	require.EqualValues(t, 4, enc.indent)
 }
+
+func TestOptionCycleHandling(t *testing.T) {
+
+	enc := &Encoder{}
+
+	opt := OptionCycleHandling(CycleHandlingError)
+
+	require.NoError(t, opt(enc))
+	require.EqualValues(t, CycleHandlingError, enc.cycleHandling)
+
+	opt = OptionCycleHandling(CycleHandling(-1))
+	require.EqualError(t, opt(enc), ErrInvalidCycleHandling.Error())
+}
+
+func TestOptionMaxDepth(t *testing.T) {
+
+	enc := &Encoder{}
+
+	opt := OptionMaxDepth(2)
+
+	require.NoError(t, opt(enc))
+	require.EqualValues(t, 2, enc.maxDepth)
+}
+
+func TestOptionMultilineMode(t *testing.T) {
+
+	enc := &Encoder{}
+
+	opt := OptionMultilineMode(MultilineQuoted)
+
+	require.NoError(t, opt(enc))
+	require.EqualValues(t, MultilineQuoted, enc.multilineMode)
+
+	opt = OptionMultilineMode(MultilineMode(-1))
+	require.EqualError(t, opt(enc), ErrInvalidMultilineMode.Error())
+}
+
+func TestOptionTables(t *testing.T) {
+
+	enc := &Encoder{}
+
+	opt := OptionTables(true)
+
+	require.NoError(t, opt(enc))
+	require.True(t, enc.tables)
+}
+
+func TestOptionTheme(t *testing.T) {
+
+	enc := &Encoder{}
+
+	opt := OptionTheme(ThemeMonochrome)
+
+	require.NoError(t, opt(enc))
+	require.EqualValues(t, ThemeMonochrome, enc.theme)
+}
+
+func TestOptionColorMode(t *testing.T) {
+
+	enc := &Encoder{}
+
+	opt := OptionColorMode(ColorAlways)
+
+	require.NoError(t, opt(enc))
+	require.EqualValues(t, ColorAlways, enc.colorMode)
+
+	opt = OptionColorMode(ColorMode(-1))
+	require.EqualError(t, opt(enc), ErrInvalidColorMode.Error())
+}
+
+func TestOptionWidth(t *testing.T) {
+
+	enc := &Encoder{}
+
+	opt := OptionWidth(80)
+
+	require.NoError(t, opt(enc))
+	require.EqualValues(t, 80, enc.width)
+}
+
+func TestOptionStreaming(t *testing.T) {
+
+	enc := &Encoder{}
+
+	opt := OptionStreaming(4096)
+
+	require.NoError(t, opt(enc))
+	require.EqualValues(t, 4096, enc.streamLimit)
+}
+
+func TestOptionTypeFormatter(t *testing.T) {
+
+	enc := &Encoder{}
+
+	formatter := func(reflect.Value) (string, error) {
+		return "", nil
+	}
+	opt := OptionTypeFormatter(reflect.TypeOf(""), formatter)
+
+	require.NoError(t, opt(enc))
+	require.Contains(t, enc.typeFormatters, reflect.TypeOf(""))
+
+	opt = OptionTypeFormatter(nil, formatter)
+	require.EqualError(t, opt(enc), ErrInvalidTypeFormatter.Error())
+}
+
+func TestOptionInterfaceFormatter(t *testing.T) {
+
+	enc := &Encoder{}
+
+	formatter := func(reflect.Value) (string, error) {
+		return "", nil
+	}
+	opt := OptionInterfaceFormatter(reflect.TypeOf((*error)(nil)).Elem(), formatter)
+
+	require.NoError(t, opt(enc))
+	require.Len(t, enc.interfaceFormatters, 1)
+
+	opt = OptionInterfaceFormatter(reflect.TypeOf(""), formatter)
+	require.EqualError(t, opt(enc), ErrInvalidTypeFormatter.Error())
+}
+
+func TestOptionTimeFormat(t *testing.T) {
+
+	enc := &Encoder{}
+
+	opt := OptionTimeFormat(time.RFC1123)
+
+	require.NoError(t, opt(enc))
+	require.EqualValues(t, time.RFC1123, enc.timeFormat)
+
+	opt = OptionTimeFormat("")
+	require.EqualError(t, opt(enc), ErrInvalidTimeFormat.Error())
+}
+
+func TestOptionTimeLocation(t *testing.T) {
+
+	enc := &Encoder{}
+
+	opt := OptionTimeLocation(time.UTC)
+
+	require.NoError(t, opt(enc))
+	require.EqualValues(t, time.UTC, enc.timeLocation)
+}
+
+func TestOptionRelativeTime(t *testing.T) {
+
+	enc := &Encoder{}
+
+	opt := OptionRelativeTime(true)
+
+	require.NoError(t, opt(enc))
+	require.True(t, enc.relativeTime)
+}
+
+func TestOptionClock(t *testing.T) {
+
+	enc := &Encoder{}
+
+	now := time.Date(2017, 10, 9, 12, 0, 0, 0, time.UTC)
+	opt := OptionClock(func() time.Time {
+		return now
+	})
+
+	require.NoError(t, opt(enc))
+	require.EqualValues(t, now, enc.clock())
+
+	opt = OptionClock(nil)
+	require.EqualError(t, opt(enc), ErrInvalidClock.Error())
+}
+
+func TestOptionFieldNameTransform(t *testing.T) {
+
+	enc := &Encoder{}
+
+	opt := OptionFieldNameTransform(FieldNameSnakeCase)
+	require.NoError(t, opt(enc))
+	require.EqualValues(t, "created_at", enc.fieldNameTransform("CreatedAt"))
+
+	opt = OptionFieldNameTransform(nil)
+	require.EqualError(t, opt(enc), ErrInvalidFieldNameTransform.Error())
+}
+
+func TestOptionRedactor(t *testing.T) {
+
+	enc := &Encoder{}
+
+	opt := OptionRedactor(RedactPartial)
+	require.NoError(t, opt(enc))
+	require.EqualValues(t, "****6789", enc.redactor("123456789"))
+
+	opt = OptionRedactor(nil)
+	require.EqualError(t, opt(enc), ErrInvalidRedactor.Error())
+}
+
+func TestOptionSortFields(t *testing.T) {
+
+	enc := &Encoder{}
+
+	opt := OptionSortFields(true)
+	require.NoError(t, opt(enc))
+	require.True(t, enc.sortFields)
+}
+
+func TestOptionKeyLess(t *testing.T) {
+
+	enc := &Encoder{}
+
+	opt := OptionKeyLess(func(a, b reflect.Value) bool {
+		return a.Int() < b.Int()
+	})
+	require.NoError(t, opt(enc))
+	require.True(t, enc.keyLess(reflect.ValueOf(1), reflect.ValueOf(2)))
+
+	opt = OptionKeyLess(nil)
+	require.EqualError(t, opt(enc), ErrInvalidKeyLess.Error())
+}
+
+func TestOptionPartialOutput(t *testing.T) {
+
+	enc := &Encoder{}
+
+	opt := OptionPartialOutput(true)
+	require.NoError(t, opt(enc))
+	require.True(t, enc.partialOutput)
+}
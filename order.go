@@ -0,0 +1,119 @@
+package human
+
+import (
+	"reflect"
+	"sort"
+)
+
+// FieldOrderer is implemented by struct types which define the order their fields are rendered in.
+// HumanFieldOrder returns the Go names of the fields which are rendered first, in the given order.
+// All other fields follow, ordered as if the type did not implement FieldOrderer.
+type FieldOrderer interface {
+	HumanFieldOrder() []string
+}
+
+// orderKey holds the properties of a struct field which determine its position
+type orderKey struct {
+	// goName is the Go name of the field
+	goName string
+	// name is the name the field is rendered with
+	name string
+	tag  tagInfo
+}
+
+// fieldSorter sorts the indices of struct fields by their orderKey
+type fieldSorter struct {
+	indices []int
+	keys    []orderKey
+	// ranks maps the Go names returned by HumanFieldOrder to their position
+	ranks map[string]int
+	// alphabetical defines if fields are sorted by name
+	alphabetical bool
+}
+
+func (s *fieldSorter) Len() int {
+	return len(s.indices)
+}
+
+func (s *fieldSorter) Swap(i, j int) {
+	s.indices[i], s.indices[j] = s.indices[j], s.indices[i]
+}
+
+func (s *fieldSorter) Less(i, j int) bool {
+	a, b := s.keys[s.indices[i]], s.keys[s.indices[j]]
+
+	// Fields listed by HumanFieldOrder come first
+	rankA, rankedA := s.ranks[a.goName]
+	rankB, rankedB := s.ranks[b.goName]
+	if rankedA != rankedB {
+		return rankedA
+	} else if rankedA && rankA != rankB {
+		return rankA < rankB
+	}
+
+	// Fields with the order tag option follow
+	if a.tag.ordered != b.tag.ordered {
+		return a.tag.ordered
+	} else if a.tag.ordered && a.tag.order != b.tag.order {
+		return a.tag.order < b.tag.order
+	}
+
+	if s.alphabetical {
+		return a.name < b.name
+	}
+	return false
+}
+
+// fieldOrder returns the order in which the fields described by keys are rendered, as indices into keys.
+// The value v is the struct which may implement FieldOrderer, it may be invalid if no value is at hand.
+func (e *Encoder) fieldOrder(v reflect.Value, keys []orderKey) []int {
+	sorter := &fieldSorter{
+		indices:      make([]int, len(keys)),
+		keys:         keys,
+		alphabetical: e.sortFields,
+	}
+	for i := range sorter.indices {
+		sorter.indices[i] = i
+	}
+
+	if order := humanFieldOrder(v); len(order) > 0 {
+		sorter.ranks = make(map[string]int, len(order))
+		for rank, goName := range order {
+			if _, exists := sorter.ranks[goName]; !exists {
+				sorter.ranks[goName] = rank
+			}
+		}
+	} else if !sorter.alphabetical && !hasOrderedField(keys) {
+		// Nothing to sort by, so the fields keep their order of declaration
+		return sorter.indices
+	}
+
+	sort.Stable(sorter)
+	return sorter.indices
+}
+
+// hasOrderedField checks if any of the fields described by keys has the order tag option set
+func hasOrderedField(keys []orderKey) bool {
+	for _, key := range keys {
+		if key.tag.ordered {
+			return true
+		}
+	}
+	return false
+}
+
+// humanFieldOrder returns the field order defined by the struct value, if it implements FieldOrderer
+func humanFieldOrder(v reflect.Value) []string {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	if orderer, ok := v.Interface().(FieldOrderer); ok {
+		return orderer.HumanFieldOrder()
+	}
+	if v.CanAddr() {
+		if orderer, ok := v.Addr().Interface().(FieldOrderer); ok {
+			return orderer.HumanFieldOrder()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package human
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type orderEmbeddedTest struct {
+	Name   string `human:",order=1"`
+	Status string `human:",order=2"`
+}
+
+type orderTest struct {
+	ID       int
+	Comment  string
+	Created  string
+	Embedded *orderEmbeddedTest `human:",omitempty"`
+	orderEmbeddedTest
+}
+
+type orderMethodTest struct {
+	Alpha string
+	Beta  string
+	Gamma string
+}
+
+func (orderMethodTest) HumanFieldOrder() []string {
+	return []string{"Gamma", "Unknown", "Alpha"}
+}
+
+type orderPointerMethodTest struct {
+	Alpha string
+	Beta  string
+}
+
+func (*orderPointerMethodTest) HumanFieldOrder() []string {
+	return []string{"Beta"}
+}
+
+func encodeOrderTest(t *testing.T, v interface{}, opts ...Option) string {
+	outputBuffer := bytes.NewBufferString("")
+	enc, err := NewEncoder(outputBuffer, opts...)
+	require.NoError(t, err)
+	require.NoError(t, enc.Encode(v))
+	return outputBuffer.String()
+}
+
+func TestEncoder_Encode_Order(t *testing.T) {
+	s := orderTest{
+		ID:      1,
+		Comment: "comment",
+		Created: "today",
+		orderEmbeddedTest: orderEmbeddedTest{
+			Name:   "test",
+			Status: "running",
+		},
+	}
+
+	t.Run("Tag", func(t *testing.T) {
+		require.EqualValues(t, "\nName: test\nStatus: running\nID: 1\nComment: comment\nCreated: today\n",
+			encodeOrderTest(t, s))
+	})
+
+	t.Run("Sorted", func(t *testing.T) {
+		require.EqualValues(t, "\nName: test\nStatus: running\nComment: comment\nCreated: today\nID: 1\n",
+			encodeOrderTest(t, s, OptionSortFields(true)))
+	})
+
+	t.Run("Method", func(t *testing.T) {
+		m := orderMethodTest{
+			Alpha: "a",
+			Beta:  "b",
+			Gamma: "c",
+		}
+		require.EqualValues(t, "\nGamma: c\nAlpha: a\nBeta: b\n", encodeOrderTest(t, m))
+	})
+
+	t.Run("PointerMethod", func(t *testing.T) {
+		m := &orderPointerMethodTest{
+			Alpha: "a",
+			Beta:  "b",
+		}
+		require.EqualValues(t, "\nBeta: b\nAlpha: a\n", encodeOrderTest(t, m))
+		require.EqualValues(t, "\nAlpha: a\nBeta: b\n", encodeOrderTest(t, *m))
+	})
+
+	t.Run("Table", func(t *testing.T) {
+		rows := []orderMethodTest{{Alpha: "a", Beta: "b", Gamma: "c"}}
+		require.EqualValues(t, "\nGamma  Alpha  Beta\nc      a      b\n",
+			encodeOrderTest(t, rows, OptionTables(true)))
+	})
+}
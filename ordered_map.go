@@ -0,0 +1,119 @@
+package human
+
+import "reflect"
+
+var _ Marshaler = OrderedMap{}
+
+// OrderedMap holds key/value pairs, which are rendered in insertion order.
+// Each pair is rendered like a struct field, using the key as field name.
+//
+// The zero value is an empty map ready to use.
+type OrderedMap struct {
+	entries []orderedMapEntry
+	// index maps the keys to the index of their entry
+	index map[string]int
+}
+
+// orderedMapEntry is a key/value pair of an OrderedMap
+type orderedMapEntry struct {
+	key       string
+	value     interface{}
+	omitEmpty bool
+}
+
+// NewOrderedMap returns a new, empty OrderedMap
+func NewOrderedMap() *OrderedMap {
+	return &OrderedMap{}
+}
+
+// Set sets the value of the given key. New keys are appended, while existing keys
+// keep their position.
+func (m *OrderedMap) Set(key string, value interface{}) *OrderedMap {
+	m.set(key, value, false)
+	return m
+}
+
+// SetOmitEmpty sets the value of the given key like Set does, but the pair is omitted if
+// the value is nil or empty, like struct fields with the omitempty tag option
+func (m *OrderedMap) SetOmitEmpty(key string, value interface{}) *OrderedMap {
+	m.set(key, value, true)
+	return m
+}
+
+func (m *OrderedMap) set(key string, value interface{}, omitEmpty bool) {
+	entry := orderedMapEntry{
+		key:       key,
+		value:     value,
+		omitEmpty: omitEmpty,
+	}
+
+	if i, ok := m.index[key]; ok {
+		m.entries[i] = entry
+		return
+	}
+
+	if m.index == nil {
+		m.index = make(map[string]int)
+	}
+	m.index[key] = len(m.entries)
+	m.entries = append(m.entries, entry)
+}
+
+// Get returns the value of the given key, along with a flag which defines if the key is present
+func (m *OrderedMap) Get(key string) (interface{}, bool) {
+	i, ok := m.index[key]
+	if !ok {
+		return nil, false
+	}
+	return m.entries[i].value, true
+}
+
+// Delete removes the given key
+func (m *OrderedMap) Delete(key string) {
+	i, ok := m.index[key]
+	if !ok {
+		return
+	}
+
+	m.entries = append(m.entries[:i], m.entries[i+1:]...)
+	delete(m.index, key)
+	for j := i; j < len(m.entries); j++ {
+		m.index[m.entries[j].key] = j
+	}
+}
+
+// Keys returns the keys in insertion order
+func (m *OrderedMap) Keys() []string {
+	keys := make([]string, len(m.entries))
+	for i, entry := range m.entries {
+		keys[i] = entry.key
+	}
+	return keys
+}
+
+// Len returns the number of keys
+func (m *OrderedMap) Len() int {
+	return len(m.entries)
+}
+
+var orderedMapType = reflect.TypeOf(OrderedMap{})
+
+// MarshalHuman renders the key/value pairs in insertion order and implements Marshaler
+func (m OrderedMap) MarshalHuman(w *Writer) error {
+	var errs EncodeErrors
+	for _, entry := range m.entries {
+		if entry.value == nil || (entry.omitEmpty && IsNilOrEmpty(entry.value, reflect.ValueOf(entry.value))) {
+			// Skip pairs like struct fields are skipped
+			continue
+		}
+
+		if fieldErrs, ok := IsEncodeErrors(w.Field(entry.key, entry.value)); ok {
+			errs = append(errs, fieldErrs...)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package human
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderedMap(t *testing.T) {
+	m := NewOrderedMap()
+	require.EqualValues(t, 0, m.Len())
+
+	m.Set("b", 1).Set("a", 2).SetOmitEmpty("c", "")
+	require.EqualValues(t, []string{"b", "a", "c"}, m.Keys())
+
+	m.Set("b", 3)
+	require.EqualValues(t, []string{"b", "a", "c"}, m.Keys())
+	value, ok := m.Get("b")
+	require.True(t, ok)
+	require.EqualValues(t, 3, value)
+
+	m.Delete("b")
+	m.Delete("unknown")
+	require.EqualValues(t, []string{"a", "c"}, m.Keys())
+	_, ok = m.Get("b")
+	require.False(t, ok)
+	value, ok = m.Get("c")
+	require.True(t, ok)
+	require.EqualValues(t, "", value)
+
+	var zero OrderedMap
+	zero.Set("key", "value")
+	require.EqualValues(t, []string{"key"}, zero.Keys())
+}
+
+func TestEncoder_Encode_OrderedMap(t *testing.T) {
+	inner := NewOrderedMap().
+		Set("Zone", "b").
+		Set("Region", "eu")
+
+	m := NewOrderedMap().
+		Set("Name", "test").
+		SetOmitEmpty("Comment", "").
+		Set("Status", "running").
+		Set("Nil", nil).
+		Set("Location", inner).
+		Set("Tags", []string{"a", "b"})
+
+	t.Run("TopLevel", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer)
+		require.NoError(t, err)
+
+		require.NoError(t, enc.Encode(m))
+		require.EqualValues(t, `
+Name: test
+Status: running
+Location:
+  Zone: b
+  Region: eu
+Tags:
+  * a
+  * b
+`, outputBuffer.String())
+	})
+
+	t.Run("List", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer)
+		require.NoError(t, err)
+
+		s := struct {
+			Items []OrderedMap
+		}{
+			Items: []OrderedMap{*inner, *inner},
+		}
+		require.NoError(t, enc.Encode(s))
+		require.EqualValues(t, `
+Items:
+  * Zone: b
+    Region: eu
+  * Zone: b
+    Region: eu
+`, outputBuffer.String())
+	})
+
+	t.Run("MaxDepth", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer, OptionMaxDepth(1))
+		require.NoError(t, err)
+
+		// OrderedMaps below the maximum depth are collapsed like maps
+		s := struct {
+			Map     map[string]int
+			Ordered *OrderedMap
+			Items   []OrderedMap
+		}{
+			Map:     map[string]int{"one": 1},
+			Ordered: NewOrderedMap().Set("one", 1).Set("two", 2),
+			Items:   []OrderedMap{*inner},
+		}
+		require.NoError(t, enc.Encode(s))
+		require.EqualValues(t, "\nMap: <map: 1 key>\nOrdered: <map: 2 keys>\nItems: <1 item>\n", outputBuffer.String())
+
+		outputBuffer.Reset()
+		enc, err = NewEncoder(outputBuffer, OptionMaxDepth(2))
+		require.NoError(t, err)
+		require.NoError(t, enc.Encode(s))
+		require.EqualValues(t, "\nMap:\n  * one: 1\nOrdered:\n  one: 1\n  two: 2\nItems:\n  * <map: 2 keys>\n", outputBuffer.String())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer)
+		require.NoError(t, err)
+
+		err = enc.Encode(NewOrderedMap().Set("Value", failingMarshaler{}))
+		require.Error(t, err)
+		require.EqualValues(t, errMarshalerTest, findEncodeError(t, err, "Value").Err)
+	})
+}
@@ -0,0 +1,34 @@
+package human
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// formatErrorPlaceholder returns the placeholder which is rendered in place of a value that
+// could not be encoded, if partial output is enabled
+func formatErrorPlaceholder(err error) string {
+	return fmt.Sprintf("<error: %s>", firstLine(err.Error()))
+}
+
+// errorPlaceholder returns the colorized placeholder for a value that could not be encoded
+func (e *Encoder) errorPlaceholder(err error) string {
+	return e.colorize(e.theme.Error, formatErrorPlaceholder(err))
+}
+
+// handleError records a non-nil error of the given value at the current path and returns true,
+// in which case the value must not be rendered. If partial output is enabled, the placeholder is
+// rendered in place of the value, otherwise only the line is terminated, as streamed output may have
+// been written already.
+func (e *encodeState) handleError(v reflect.Value, err error) bool {
+	if err == nil {
+		return false
+	}
+	e.fail(valueType(v), err)
+	if e.partialOutput {
+		fmt.Fprintln(e.stream, "", e.errorPlaceholder(err))
+	} else {
+		fmt.Fprintln(e.stream, "")
+	}
+	return true
+}
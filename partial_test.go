@@ -0,0 +1,154 @@
+package human
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errTextMarshalerTest = errors.New("marshal failed")
+
+type failingTextMarshaler struct{}
+
+func (failingTextMarshaler) MarshalText() ([]byte, error) {
+	return nil, errTextMarshalerTest
+}
+
+type partialTest struct {
+	Name     string
+	Address  failingTextMarshaler
+	Items    []interface{}
+	Values   map[string]interface{}
+	Rendered failingMarshaler
+	Status   string
+}
+
+func TestEncoder_Encode_PartialOutput(t *testing.T) {
+	s := partialTest{
+		Name:   "test",
+		Items:  []interface{}{1, failingTextMarshaler{}, 3},
+		Values: map[string]interface{}{"a": failingTextMarshaler{}, "b": 2},
+		Status: "running",
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer)
+		require.NoError(t, err)
+
+		require.Error(t, enc.Encode(s))
+		require.EqualValues(t, "", outputBuffer.String())
+	})
+
+	t.Run("DisabledStreaming", func(t *testing.T) {
+		// Streamed output is written already, failing values leave no partial lines behind
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer, OptionStreaming(1))
+		require.NoError(t, err)
+
+		err = enc.Encode(struct {
+			A failingTextMarshaler
+			B string
+		}{B: "b"})
+		require.Error(t, err)
+		require.EqualValues(t, "\nA:\nB: b\n", outputBuffer.String())
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer, OptionPartialOutput(true))
+		require.NoError(t, err)
+
+		err = enc.Encode(s)
+		require.Error(t, err)
+		require.EqualValues(t, errTextMarshalerTest, findEncodeError(t, err, "Address").Err)
+		require.EqualValues(t, errMarshalerTest, findEncodeError(t, err, "Rendered").Err)
+		require.EqualValues(t, errTextMarshalerTest, findEncodeError(t, err, "Items[1]").Err)
+		require.EqualValues(t, errTextMarshalerTest, findEncodeError(t, err, "Values[a]").Err)
+		require.EqualValues(t, `
+Name: test
+Address: <error: marshal failed>
+Items:
+  * 1
+  * <error: marshal failed>
+  * 3
+Values:
+  * a: <error: marshal failed>
+  * b: 2
+Rendered:
+  partial
+  <error: marshaler test error>
+Status: running
+`, outputBuffer.String())
+	})
+
+	t.Run("MarshalerWithoutOutput", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer, OptionPartialOutput(true))
+		require.NoError(t, err)
+
+		err = enc.Encode(NewOrderedMap().Set("Address", failingTextMarshaler{}))
+		require.Error(t, err)
+		require.EqualValues(t, "\nAddress: <error: marshal failed>\n", outputBuffer.String())
+	})
+
+	t.Run("Formatter", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer, OptionPartialOutput(true), OptionTypeFormatter(reflect.TypeOf(0), func(reflect.Value) (string, error) {
+			return "", errors.New("format failed")
+		}))
+		require.NoError(t, err)
+
+		err = enc.Encode(struct {
+			Count  int
+			Status string
+		}{Count: 1, Status: "ok"})
+		require.Error(t, err)
+		require.EqualValues(t, "\nCount: <error: format failed>\nStatus: ok\n", outputBuffer.String())
+	})
+
+	t.Run("Table", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer, OptionPartialOutput(true), OptionTables(true))
+		require.NoError(t, err)
+
+		type row struct {
+			Name    string
+			Address failingTextMarshaler
+		}
+		err = enc.Encode([]row{{Name: "a"}, {Name: "b"}})
+		require.Error(t, err)
+		require.EqualValues(t, errTextMarshalerTest, findEncodeError(t, err, "[0].Address").Err)
+		require.EqualValues(t, errTextMarshalerTest, findEncodeError(t, err, "[1].Address").Err)
+		require.EqualValues(t, `
+Name  Address
+a     <error: marshal failed>
+b     <error: marshal failed>
+`, outputBuffer.String())
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer, OptionPartialOutput(true), OptionCycleHandling(CycleHandlingError))
+		require.NoError(t, err)
+
+		type node struct {
+			Name string
+			Next *node
+		}
+		n := &node{Name: "a"}
+		n.Next = n
+		err = enc.Encode(n)
+		require.Error(t, err)
+		require.EqualValues(t, "\nName: a\nNext: <error: cycle detected>\n", outputBuffer.String())
+	})
+
+	t.Run("Sprint", func(t *testing.T) {
+		text, err := Sprint(s.Address, OptionPartialOutput(true))
+		require.Error(t, err)
+		require.EqualValues(t, "<error: marshal failed>\n", text)
+	})
+}
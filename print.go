@@ -0,0 +1,73 @@
+package human
+
+import (
+	"bytes"
+	"io"
+	"os"
+)
+
+// Marshal returns the human encoding of v.
+//
+// In contrast to Encoder.Encode, the separator which is written in front of top-level
+// values is omitted, so the output starts with the value itself.
+// If an error occurs, the output written up to that point is returned along with the error,
+// which is only the case if OptionPartialOutput or OptionStreaming is used.
+func Marshal(v interface{}, opts ...Option) ([]byte, error) {
+	buffer := bytes.NewBufferString("")
+	if err := Fprint(buffer, v, opts...); err != nil {
+		if buffer.Len() == 0 {
+			return nil, err
+		}
+		return buffer.Bytes(), err
+	}
+	return buffer.Bytes(), nil
+}
+
+// MarshalIndent is like Marshal, but uses the given indentation.
+func MarshalIndent(v interface{}, indent uint, opts ...Option) ([]byte, error) {
+	return Marshal(v, append(opts, OptionIndent(indent))...)
+}
+
+// Sprint returns the human encoding of v as string.
+func Sprint(v interface{}, opts ...Option) (string, error) {
+	data, err := Marshal(v, opts...)
+	return string(data), err
+}
+
+// Fprint writes the human encoding of v to w.
+func Fprint(w io.Writer, v interface{}, opts ...Option) error {
+	enc, err := NewEncoder(w, opts...)
+	if err != nil {
+		return err
+	}
+	// Replace the writer after the encoder has been created, as color detection
+	// depends on the actual writer
+	enc.writer = &separatorTrimmer{
+		writer: w,
+	}
+	return enc.Encode(v)
+}
+
+// Print writes the human encoding of v to the standard output.
+func Print(v interface{}, opts ...Option) error {
+	return Fprint(os.Stdout, v, opts...)
+}
+
+// separatorTrimmer wraps around an io.Writer and drops the separator the
+// Encoder writes in front of top-level values
+type separatorTrimmer struct {
+	writer  io.Writer
+	trimmed bool
+}
+
+// Write writes p to the underlying io.Writer, omitting a leading separator on the first call
+func (t *separatorTrimmer) Write(p []byte) (n int, err error) {
+	if !t.trimmed && len(p) > 0 {
+		t.trimmed = true
+		if p[0] == '\n' || p[0] == ' ' {
+			n, err = t.writer.Write(p[1:])
+			return n + 1, err
+		}
+	}
+	return t.writer.Write(p)
+}
@@ -0,0 +1,59 @@
+package human
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/stretchr/testify/require"
+)
+
+type printTest struct {
+	Name  string
+	Child struct {
+		Value int
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	s := printTest{Name: "test"}
+	s.Child.Value = 1
+
+	data, err := Marshal(s)
+	require.NoError(t, err)
+	require.EqualValues(t, "Name: test\nChild:\n  Value: 1\n", string(data))
+
+	data, err = Marshal("test")
+	require.NoError(t, err)
+	require.EqualValues(t, "test\n", string(data))
+
+	data, err = Marshal([]int{1, 2}, OptionListSymbols("-"))
+	require.NoError(t, err)
+	require.EqualValues(t, "- 1\n- 2\n", string(data))
+
+	_, err = Marshal(s, OptionListSymbols())
+	require.Error(t, err)
+	_, ok := err.(*multierror.Error)
+	require.True(t, ok, "Must be a *multierror.Error")
+}
+
+func TestMarshalIndent(t *testing.T) {
+	s := printTest{Name: "test"}
+	s.Child.Value = 1
+
+	data, err := MarshalIndent(s, 4)
+	require.NoError(t, err)
+	require.EqualValues(t, "Name: test\nChild:\n    Value: 1\n", string(data))
+}
+
+func TestSprint(t *testing.T) {
+	text, err := Sprint(map[string]int{"a": 1})
+	require.NoError(t, err)
+	require.EqualValues(t, "* a: 1\n", text)
+}
+
+func TestFprint(t *testing.T) {
+	outputBuffer := bytes.NewBufferString("")
+	require.NoError(t, Fprint(outputBuffer, printTest{Name: "test"}, OptionStreaming(1)))
+	require.EqualValues(t, "Name: test\nChild:\n  Value: 0\n", outputBuffer.String())
+}
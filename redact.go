@@ -0,0 +1,54 @@
+package human
+
+import (
+	"reflect"
+	"strings"
+)
+
+// redactedLength defines the number of mask characters a value is replaced with,
+// which is independent of the value's length
+const redactedLength = 8
+
+// redactedVisible defines the number of trailing characters RedactPartial keeps visible
+const redactedVisible = 4
+
+// Redactor defines the function type used for rendering the values of fields that have the
+// "redact" or "secret" tag option. It receives the value as it would have been rendered
+// and returns the text that is rendered instead.
+type Redactor func(value string) string
+
+// RedactFull replaces the whole value with "********"
+func RedactFull(value string) string {
+	return strings.Repeat("*", redactedLength)
+}
+
+// RedactPartial replaces all but the last four characters of the value, like "****1234".
+// Values of up to eight characters are replaced completely, as revealing their last four
+// characters would reveal too much of them.
+func RedactPartial(value string) string {
+	runes := []rune(value)
+	if len(runes) <= redactedLength {
+		return RedactFull(value)
+	}
+	return strings.Repeat("*", redactedLength-redactedVisible) + string(runes[len(runes)-redactedVisible:])
+}
+
+// isContainer checks if the value is a slice, array or map, whose elements are redacted
+// individually
+func isContainer(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// redact renders a value using the Encoder's Redactor
+func (e *Encoder) redact(v reflect.Value, tag tagInfo) (string, error) {
+	text, err := e.formatInline(v, tag)
+	if err != nil {
+		// Do not risk rendering a partial value
+		return RedactFull(text), err
+	}
+	return e.redactor(text), nil
+}
@@ -0,0 +1,95 @@
+package human
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactFull(t *testing.T) {
+	require.EqualValues(t, "********", RedactFull(""))
+	require.EqualValues(t, "********", RedactFull("secret"))
+	require.EqualValues(t, "********", RedactFull("a very long secret"))
+}
+
+func TestRedactPartial(t *testing.T) {
+	require.EqualValues(t, "********", RedactPartial("1234"))
+	require.EqualValues(t, "********", RedactPartial("12345678"))
+	require.EqualValues(t, "****6789", RedactPartial("123456789"))
+	require.EqualValues(t, "****äöüß", RedactPartial("token-äöüß"))
+}
+
+type redactTest struct {
+	User     string
+	Password string            `human:",redact"`
+	Token    string            `human:"API token,secret"`
+	PIN      int               `human:",secret"`
+	Keys     []string          `human:",redact"`
+	Headers  map[string]string `human:",redact,omitempty"`
+	Empty    string            `human:",redact,omitempty"`
+}
+
+func TestEncoder_Encode_Redact(t *testing.T) {
+	s := redactTest{
+		User:     "admin",
+		Password: "hunter2",
+		Token:    "abcdefgh12345678",
+		PIN:      1234,
+		Keys:     []string{"first-key-1111", "second-key-2222"},
+		Headers: map[string]string{
+			"Authorization": "Bearer abcdefgh",
+		},
+	}
+
+	t.Run("Full", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer)
+		require.NoError(t, err)
+
+		require.NoError(t, enc.Encode(s))
+		require.EqualValues(t, `
+User: admin
+Password: ********
+API token: ********
+PIN: ********
+Keys:
+  * ********
+  * ********
+Headers:
+  * Authorization: ********
+`, outputBuffer.String())
+	})
+
+	t.Run("Partial", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer, OptionRedactor(RedactPartial))
+		require.NoError(t, err)
+
+		require.NoError(t, enc.Encode(s))
+		require.EqualValues(t, `
+User: admin
+Password: ********
+API token: ****5678
+PIN: ********
+Keys:
+  * ****1111
+  * ****2222
+Headers:
+  * Authorization: ****efgh
+`, outputBuffer.String())
+	})
+
+	t.Run("Table", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer, OptionTables(true))
+		require.NoError(t, err)
+
+		type row struct {
+			User     string
+			Password string `human:",secret"`
+		}
+		require.NoError(t, enc.Encode([]row{{User: "admin", Password: "hunter2"}}))
+		require.EqualValues(t, "\nUser   Password\nadmin  ********\n", outputBuffer.String())
+	})
+}
@@ -0,0 +1,285 @@
+package human
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// tableColumnSeparator defines the whitespace between two table columns
+const tableColumnSeparator = "  "
+
+// tableColumn describes a column of a table that is rendered from a slice of structs
+type tableColumn struct {
+	index []int
+	// goName is the Go name of the field
+	goName string
+	tag    tagInfo
+}
+
+var (
+	marshalerType     = reflect.TypeOf((*Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	stringerType      = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// isTableSlice checks if the given slice or array type holds structs or struct pointers which
+// are rendered as table. Structs which render themselves, like time.Time or types implementing
+// Marshaler, fmt.Stringer and the like, are rendered as list instead.
+func (e *Encoder) isTableSlice(t reflect.Type) bool {
+	elemType := t.Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct || structType == timeType || e.hasFormatter(elemType) {
+		return false
+	}
+
+	// Like encodeValue, only the methods of the element type itself are taken into account
+	for _, iface := range []reflect.Type{marshalerType, textMarshalerType, stringerType, errorType} {
+		if elemType.Implements(iface) {
+			return false
+		}
+	}
+	return true
+}
+
+// tableColumns returns the columns of a table for the given struct type, including the
+// fields of anonymous structs. Anonymous structs whose type has been visited already are skipped.
+// Fields whose tag cannot be parsed are skipped, the returned errors hold their Go names as path.
+func (e *Encoder) tableColumns(t reflect.Type, index []int, visited map[reflect.Type]bool) (columns []tableColumn, errs EncodeErrors) {
+	visited[t] = true
+	for _, field := range e.structInfo(t).fields {
+		fieldIndex := append(append([]int{}, index...), field.index)
+
+		if field.anonymous {
+			// Anonymous structs and struct pointers contribute their fields
+			fieldType := t.Field(field.index).Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if visited[fieldType] {
+				continue
+			}
+			anonymousColumns, anonymousErrs := e.tableColumns(fieldType, fieldIndex, visited)
+			columns = append(columns, anonymousColumns...)
+			errs = append(errs, anonymousErrs...)
+			continue
+		}
+
+		if field.tagErr != nil {
+			// Parsing the tag failed, ignore the field and carry on
+			errs = append(errs, newEncodeError(field.name, t.Field(field.index).Type, field.tagErr))
+			continue
+		}
+
+		columns = append(columns, tableColumn{
+			index:  fieldIndex,
+			goName: field.name,
+			tag:    field.tag,
+		})
+	}
+	return
+}
+
+// orderColumns returns the columns in the order the fields of the given struct type are rendered in
+func (e *Encoder) orderColumns(t reflect.Type, columns []tableColumn) []tableColumn {
+	keys := make([]orderKey, len(columns))
+	for i, column := range columns {
+		keys[i] = orderKey{
+			goName: column.goName,
+			name:   e.fieldName(column.tag),
+			tag:    column.tag,
+		}
+	}
+
+	// The order defined by FieldOrderer implementations is taken from the type's zero value
+	ordered := make([]tableColumn, 0, len(columns))
+	for _, i := range e.fieldOrder(reflect.New(t).Elem(), keys) {
+		ordered = append(ordered, columns[i])
+	}
+	return ordered
+}
+
+// fieldByIndex returns the nested field of a struct, like reflect.Value.FieldByIndex does.
+// In contrast to reflect.Value.FieldByIndex, the flag is false if a nil pointer is encountered.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, fieldIndex := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(fieldIndex)
+	}
+	return v, true
+}
+
+// encodeTable renders a slice of structs as a table, with one column per field and
+// a header row holding the field names.
+// Columns of fields with the omitempty flag set are omitted if the field is empty in all rows.
+// If no column remains, nothing is written and false is returned, so the slice can be rendered
+// as list instead.
+func (e *encodeState) encodeTable(v reflect.Value, indentLevel int) bool {
+	elemType := v.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+
+	errCount := len(e.errs)
+	columns, columnErrs := e.tableColumns(elemType, nil, map[reflect.Type]bool{})
+	for _, columnErr := range columnErrs {
+		e.fail(columnErr.Type, columnErr.Err, columnErr.Path)
+	}
+	columns = e.orderColumns(elemType, columns)
+
+	rows := make([][]string, v.Len())
+	styles := make([][]Style, v.Len())
+	used := make([]bool, len(columns))
+	for i := range rows {
+		rows[i] = make([]string, len(columns))
+		styles[i] = make([]Style, len(columns))
+
+		rowValue := v.Index(i)
+		if rowValue.Kind() == reflect.Ptr {
+			if rowValue.IsNil() {
+				// nil pointers are rendered as empty row
+				continue
+			}
+			rowValue = rowValue.Elem()
+		}
+
+		for j, column := range columns {
+			fieldValue, ok := fieldByIndex(rowValue, column.index)
+			if !ok || (column.tag.omitEmpty && IsNilOrEmpty(fieldValue.Interface(), fieldValue)) {
+				continue
+			}
+			used[j] = true
+
+			cell, cellErr := e.formatCell(fieldValue, column.tag)
+			style := e.valueStyle(fieldValue)
+			if cellErr != nil {
+				e.fail(valueType(fieldValue), cellErr, fmt.Sprintf("[%d]", i), e.fieldName(column.tag))
+				if e.partialOutput {
+					cell, style = formatErrorPlaceholder(cellErr), e.theme.Error
+				}
+			}
+			rows[i][j] = cell
+			styles[i][j] = style
+		}
+	}
+
+	// Determine the columns to render and their widths
+	var visibleColumns []int
+	widths := make([]int, len(columns))
+	header := make([]string, len(columns))
+	for j, column := range columns {
+		header[j] = e.fieldName(column.tag)
+	}
+
+	for j, column := range columns {
+		if column.tag.omitEmpty && !used[j] {
+			continue
+		}
+		visibleColumns = append(visibleColumns, j)
+		widths[j] = utf8.RuneCountInString(header[j])
+		for _, row := range rows {
+			if width := utf8.RuneCountInString(row[j]); width > widths[j] {
+				widths[j] = width
+			}
+		}
+	}
+
+	if len(visibleColumns) == 0 {
+		// The errors are recorded again when rendering the list
+		e.errs = e.errs[:errCount]
+		return false
+	}
+
+	headerStyles := make([]Style, len(columns))
+	for j := range columns {
+		headerStyles[j] = e.theme.Key
+	}
+	rows = append([][]string{header}, rows...)
+	styles = append([][]Style{headerStyles}, styles...)
+
+	indent := strings.Repeat(" ", int(e.indent)*indentLevel)
+	for i, row := range rows {
+		line := indent
+		for k, j := range visibleColumns {
+			line += e.colorize(styles[i][j], row[j])
+			if k < len(visibleColumns)-1 {
+				line += strings.Repeat(" ", widths[j]-utf8.RuneCountInString(row[j])) + tableColumnSeparator
+			}
+		}
+		fmt.Fprintln(e.stream, strings.TrimRight(line, " "))
+	}
+	return true
+}
+
+// formatCell returns the single-line text of a table cell, taking the column's
+// format, time and redact tag options into account
+func (e *Encoder) formatCell(v reflect.Value, tag tagInfo) (string, error) {
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		return "", nil
+	}
+
+	if tag.redact {
+		return e.redact(v, tag)
+	}
+
+	text, err := e.formatInline(v, tag)
+	return firstLine(text), err
+}
+
+// formatInline returns the text of a value that is rendered without nesting, which may span
+// multiple lines. Structs, slices and maps are summarized.
+func (e *Encoder) formatInline(v reflect.Value, tag tagInfo) (string, error) {
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		return "", nil
+	}
+
+	if text, ok := formatNumber(v, tag.format); ok {
+		return text, nil
+	}
+
+	formatter, formatterValue, hasFormatter := e.lookupFormatter(v)
+	if t, ok := timeValue(v); ok && (tag.time != "" || !hasFormatter) {
+		return e.formatTime(t, tag.time), nil
+	}
+
+	if hasFormatter {
+		return formatter(formatterValue)
+	}
+
+	i := v.Interface()
+	if marshaler, ok := i.(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		return string(text), err
+	} else if stringer, ok := i.(fmt.Stringer); ok {
+		return stringer.String(), nil
+	}
+
+	if v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		return e.formatInline(v.Elem(), tag)
+	}
+
+	// Structs, slices and maps cannot be rendered inline
+	if summary, ok := e.summarize(v); ok {
+		return summary, nil
+	}
+	return fmt.Sprint(i), nil
+}
+
+// firstLine returns the first line of a text, followed by an ellipsis if the text spans multiple lines
+func firstLine(text string) string {
+	text = strings.TrimRight(strings.Replace(text, "\r\n", "\n", -1), "\n")
+	if index := strings.Index(text, "\n"); index >= 0 {
+		return text[:index] + " ..."
+	}
+	return text
+}
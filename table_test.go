@@ -0,0 +1,139 @@
+package human
+
+import (
+	"bytes"
+	"net"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type tableEmbeddedTest struct {
+	Embedded string
+}
+
+type tableRowTest struct {
+	*tableEmbeddedTest
+	Name  string
+	IP    net.IP
+	Note  string `human:",omitempty"`
+	Tags  []string
+	Child *tableRowTest `human:",omitempty"`
+}
+
+type tableMarshalerTest struct {
+	Name string
+}
+
+func (m tableMarshalerTest) MarshalHuman(w *Writer) error {
+	w.Line("marshaled " + m.Name)
+	return nil
+}
+
+type tableFormatterTest struct {
+	Name string
+}
+
+func TestEncoder_isTableSlice(t *testing.T) {
+	enc, err := NewEncoder(nil, OptionTypeFormatter(reflect.TypeOf(tableFormatterTest{}), func(v reflect.Value) (string, error) {
+		return v.Field(0).String(), nil
+	}))
+	require.NoError(t, err)
+
+	require.True(t, enc.isTableSlice(reflect.TypeOf([]tableRowTest{})))
+	require.True(t, enc.isTableSlice(reflect.TypeOf([2]*tableRowTest{})))
+	require.False(t, enc.isTableSlice(reflect.TypeOf([]string{})))
+	require.False(t, enc.isTableSlice(reflect.TypeOf([]interface{}{})))
+	require.False(t, enc.isTableSlice(reflect.TypeOf([]time.Time{})))
+	require.False(t, enc.isTableSlice(reflect.TypeOf([]*url.URL{})))
+	require.False(t, enc.isTableSlice(reflect.TypeOf([]tableMarshalerTest{})))
+	require.False(t, enc.isTableSlice(reflect.TypeOf([]*tableFormatterTest{})))
+}
+
+func TestEncoder_Encode_Table(t *testing.T) {
+	outputBuffer := bytes.NewBufferString("")
+	enc, err := NewEncoder(outputBuffer, OptionTables(true))
+	require.NoError(t, err)
+
+	rows := []*tableRowTest{
+		{
+			tableEmbeddedTest: &tableEmbeddedTest{Embedded: "e"},
+			Name:              "first",
+			IP:                net.ParseIP("127.0.0.1"),
+			Note:              "multi\nline",
+			Tags:              []string{"a", "b"},
+		},
+		nil,
+		{
+			Name: "second",
+		},
+	}
+
+	expectedOutput := "\n" +
+		"Embedded  Name    IP         Note       Tags\n" +
+		"e         first   127.0.0.1  multi ...  <2 items>\n" +
+		"\n" +
+		"          second                        <0 items>\n"
+
+	require.NoError(t, enc.Encode(rows))
+	require.EqualValues(t, expectedOutput, outputBuffer.String())
+}
+
+type tableRecursiveTest struct {
+	*tableRecursiveTest
+	Name string
+}
+
+func TestEncoder_Encode_TableRecursiveAnonymous(t *testing.T) {
+	outputBuffer := bytes.NewBufferString("")
+	enc, err := NewEncoder(outputBuffer, OptionTables(true))
+	require.NoError(t, err)
+
+	// The anonymous struct pointer of the same type contributes no further columns
+	rows := []tableRecursiveTest{
+		{Name: "a"},
+		{tableRecursiveTest: &tableRecursiveTest{Name: "inner"}, Name: "b"},
+	}
+	require.NoError(t, enc.Encode(rows))
+	require.EqualValues(t, "\nName\na\nb\n", outputBuffer.String())
+}
+
+func TestEncoder_Encode_TableFallback(t *testing.T) {
+	u, err := url.Parse("https://example.com/path")
+	require.NoError(t, err)
+
+	s := struct {
+		Times     []time.Time
+		URLs      []*url.URL
+		Marshaled []tableMarshalerTest
+		Empty     []struct {
+			Note string `human:",omitempty"`
+		}
+	}{
+		Times:     []time.Time{time.Date(2017, 10, 9, 12, 0, 0, 0, time.UTC)},
+		URLs:      []*url.URL{u},
+		Marshaled: []tableMarshalerTest{{Name: "a"}},
+		Empty: []struct {
+			Note string `human:",omitempty"`
+		}{{}},
+	}
+
+	outputBuffer := bytes.NewBufferString("")
+	enc, err := NewEncoder(outputBuffer, OptionTables(true), OptionTimeFormat(time.RFC3339))
+	require.NoError(t, err)
+
+	require.NoError(t, enc.Encode(s))
+	require.EqualValues(t, `
+Times:
+  * 2017-10-09T12:00:00Z
+URLs:
+  * https://example.com/path
+Marshaled:
+  * marshaled a
+Empty:
+  *
+`, outputBuffer.String())
+}
@@ -3,6 +3,7 @@ package human
 import (
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 	"unicode"
 )
@@ -33,18 +34,188 @@ func newErrorInvalidTag(tag string) error {
 // IsInvalidTag checks if the given error is an InvalidTag error
 // and returns the InvalidTag error along with a boolean that defines
 // if it is indeed an invalid tag error.
+// The error may also be the cause of an EncodeError or be aggregated by EncodeErrors,
+// in which case the first InvalidTag is returned.
 // The returned *InvalidTag may be nil, if the flag is false
-func IsInvalidTag(err error) (*InvalidTag, bool) {
-	it, ok := err.(*InvalidTag)
-	return it, ok
+func IsInvalidTag(err error) (it *InvalidTag, ok bool) {
+	ok = walkError(err, func(err error) bool {
+		it, ok = err.(*InvalidTag)
+		return ok
+	})
+	return
+}
+
+var _ error = (*UnknownTagOption)(nil)
+
+// UnknownTagOption is an error that indicates that the tag contains an option which is not known
+type UnknownTagOption struct {
+	tag    string
+	option string
+}
+
+// Error returns the error string and causes UnknownTagOption to implement the error interface
+func (uto *UnknownTagOption) Error() string {
+	return fmt.Sprintf("Unknown tag option '%s' in tag: '%s'", uto.option, uto.tag)
+}
+
+// Tag returns the tag
+func (uto *UnknownTagOption) Tag() string {
+	return uto.tag
+}
+
+// Option returns the key of the unknown option
+func (uto *UnknownTagOption) Option() string {
+	return uto.option
+}
+
+func newErrorUnknownTagOption(tag, option string) error {
+	return &UnknownTagOption{
+		tag:    tag,
+		option: option,
+	}
+}
+
+// IsUnknownTagOption checks if the given error is an UnknownTagOption error
+// and returns the UnknownTagOption error along with a boolean that defines
+// if it is indeed an unknown tag option error.
+// The error may also be the cause of an EncodeError or be aggregated by EncodeErrors,
+// in which case the first UnknownTagOption is returned.
+// The returned *UnknownTagOption may be nil, if the flag is false
+func IsUnknownTagOption(err error) (uto *UnknownTagOption, ok bool) {
+	ok = walkError(err, func(err error) bool {
+		uto, ok = err.(*UnknownTagOption)
+		return ok
+	})
+	return
+}
+
+// TagOption is a single option of a tag, consisting of a key and an optional value.
+// For example, the option "format=bytes" has the key "format" and the value "bytes".
+type TagOption struct {
+	Key   string
+	Value string
+}
+
+// TagInfo holds the information parsed from a tag
+type TagInfo struct {
+	// Name is the name specified by the tag, which may be empty
+	Name string
+	// Options holds the options following the name, in order of appearance
+	Options []TagOption
+}
+
+// Has checks if the tag holds an option with the given key
+func (ti *TagInfo) Has(key string) bool {
+	_, ok := ti.Get(key)
+	return ok
+}
+
+// Get returns the value of the last option with the given key, along with a flag
+// which defines if the option is present at all
+func (ti *TagInfo) Get(key string) (value string, ok bool) {
+	for _, option := range ti.Options {
+		if option.Key == key {
+			value, ok = option.Value, true
+		}
+	}
+	return
+}
+
+// tagInfo holds the information of a struct field's tag, as used by the Encoder
+type tagInfo struct {
+	name      string
+	omitEmpty bool
+	table     bool
+	format    string
+	time      string
+	// order holds the position of the field, which is only taken into account if ordered is set
+	order   int
+	ordered bool
+	// redact defines if the value is replaced by the output of the Encoder's Redactor
+	redact bool
+	// ignore defines if the field is not encoded at all
+	ignore bool
+	// implicitName defines if the name is the Go name of the field, as the tag does not specify a name
+	implicitName bool
+}
+
+// elementTag returns the tagInfo which applies to the elements of slices and maps
+func (info tagInfo) elementTag() tagInfo {
+	return tagInfo{
+		format: info.format,
+		time:   info.time,
+		redact: info.redact,
+	}
+}
+
+// tagOptions maps the keys of all known tag options to functions, which apply the
+// option's value to a tagInfo and return false if the value is invalid
+var tagOptions = map[string]func(info *tagInfo, value string) bool{
+	"omitempty": func(info *tagInfo, value string) bool {
+		info.omitEmpty = true
+		return value == ""
+	},
+	"table": func(info *tagInfo, value string) bool {
+		info.table = true
+		return value == ""
+	},
+	"format": func(info *tagInfo, value string) bool {
+		info.format = value
+		return isValidFormat(value)
+	},
+	"redact": func(info *tagInfo, value string) bool {
+		info.redact = true
+		return value == ""
+	},
+	"secret": func(info *tagInfo, value string) bool {
+		info.redact = true
+		return value == ""
+	},
+	"order": func(info *tagInfo, value string) bool {
+		order, err := strconv.Atoi(value)
+		info.order, info.ordered = order, true
+		return err == nil
+	},
+	"time": func(info *tagInfo, value string) bool {
+		info.time = value
+		return value != ""
+	},
+}
+
+// parseTagFromStructField is a helper that calls parseTag given a reflect.StructField and a tag name.
+// If the field has no tag with the given name, the first of the fallback tags present on the field
+// is parsed using parseFallbackTag instead.
+func parseTagFromStructField(f reflect.StructField, tagName string, fallbackTagNames ...string) (info tagInfo, err error) {
+	tag, ok := f.Tag.Lookup(tagName)
+	if ok {
+		info, err = parseTag(tag)
+	} else {
+		for _, fallbackTagName := range fallbackTagNames {
+			if tag, ok = f.Tag.Lookup(fallbackTagName); ok {
+				info = parseFallbackTag(tag)
+				break
+			}
+		}
+	}
+	if info.name == "" {
+		info.name = f.Name
+		info.implicitName = true
+	}
+	return
 }
 
-// parseTagFromStructField is a helper that calls parseTag given a reflect.StructField and a tag name
-func parseTagFromStructField(f reflect.StructField, tagName string) (name string, omitEmpty bool, err error) {
-	tag := f.Tag.Get(tagName)
-	name, omitEmpty, err = ParseTag(tag)
-	if name == "" {
-		name = f.Name
+// parseFallbackTag parses a tag of another package, like the "json" or "yaml" tag.
+// Following the conventions of encoding/json, the tag consists of the name followed by
+// comma-separated options, of which only omitempty is used and all others are ignored.
+// The name "-" causes the field to be ignored, unless the tag is "-,", which names the field "-".
+func parseFallbackTag(tag string) (info tagInfo) {
+	parts := strings.Split(tag, ",")
+	info.name = parts[0]
+	info.ignore = tag == "-"
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			info.omitEmpty = true
+		}
 	}
 	return
 }
@@ -52,27 +223,142 @@ func parseTagFromStructField(f reflect.StructField, tagName string) (name string
 // ParseTag parses a tag string and returns the corresponding name, omitEmpty flag and a possible
 // error
 func ParseTag(tag string) (name string, omitEmpty bool, err error) {
-	name = tag
+	info, err := parseTag(tag)
+	return info.name, info.omitEmpty, err
+}
 
-	// Handle the "ignore me" tag value
-	if name == "-" {
+// ParseTagInfo parses a tag string and returns the corresponding TagInfo and a possible error.
+//
+// A tag consists of a name, followed by an arbitrary number of comma-separated options.
+// Each option is either a key or a key=value pair.
+// The name may contain spaces and punctuation. Names containing commas have to be
+// enclosed in single quotes, like 'Width, Height', or have their commas escaped using a backslash.
+// The same applies to option values, like time='Jan 2, 2006'.
+// If the tag contains an option which is not known, an *UnknownTagOption error is returned.
+// All other violations, including invalid values of known options, result in an *InvalidTag error.
+func ParseTagInfo(tag string) (*TagInfo, error) {
+	ti, _, err := parseTagInfo(tag)
+	if err != nil {
+		return nil, err
+	}
+	return ti, nil
+}
+
+// parseTag parses a tag string and returns the corresponding tagInfo and a possible error
+func parseTag(tag string) (info tagInfo, err error) {
+	_, info, err = parseTagInfo(tag)
+	return
+}
+
+// parseTagInfo parses a tag string and returns both, the TagInfo and the tagInfo
+func parseTagInfo(tag string) (ti *TagInfo, info tagInfo, err error) {
+	name, rest, ok := parseTagName(tag)
+	if !ok {
+		err = newErrorInvalidTag(tag)
 		return
 	}
+	ti = &TagInfo{
+		Name: name,
+	}
+	info.name = name
 
-	// Check if the tag has the omitempty suffix set
-	if strings.HasSuffix(tag, ",omitempty") {
-		// Update the omitEmpty flag and strip the suffix from the tag name
-		omitEmpty = true
-		name = strings.TrimSuffix(tag, ",omitempty")
+	// Handle the "ignore me" tag value, which must not be quoted
+	if name == "-" && !strings.HasPrefix(tag, "'") {
+		info.ignore = true
+		return
 	}
 
-	// Check if the rest of the tag does not contain any symbols
-	for _, letter := range name {
-		if letter != '_' && !unicode.IsLetter(letter) && !unicode.IsDigit(letter) {
+	// Handle the options following the name. Their values are parsed like names, so values
+	// containing commas, like time layouts, may be quoted.
+	for rest != "" {
+		// Skip the separator
+		rest = rest[1:]
+
+		end := strings.IndexAny(rest, ",=")
+		if end < 0 {
+			end = len(rest)
+		}
+		option := TagOption{
+			Key: rest[:end],
+		}
+		rest = rest[end:]
+		if strings.HasPrefix(rest, "=") {
+			var ok bool
+			if option.Value, rest, ok = parseTagName(rest[1:]); !ok {
+				err = newErrorInvalidTag(tag)
+				return
+			}
+		}
+
+		if option.Key == "" {
 			err = newErrorInvalidTag(tag)
 			return
 		}
+
+		apply, known := tagOptions[option.Key]
+		if !known {
+			err = newErrorUnknownTagOption(tag, option.Key)
+			return
+		} else if !apply(&info, option.Value) {
+			err = newErrorInvalidTag(tag)
+			return
+		}
+
+		ti.Options = append(ti.Options, option)
 	}
 
 	return
 }
+
+// parseTagName parses the name at the start of a tag and returns the name, along with
+// the rest of the tag starting at the separator which follows the name.
+//
+// The name may consist of any printable characters, except for the separator ",".
+// A backslash escapes the following character, allowing for names containing the separator.
+// Alternatively, the name may be enclosed in single quotes, in which case it may contain
+// the separator. Within quotes, a backslash escapes single quotes and backslashes.
+//
+// The flag is false if the name contains non-printable characters, like newlines,
+// or if a quote is not terminated.
+func parseTagName(tag string) (name string, rest string, ok bool) {
+	runes := []rune(tag)
+	quoted := strings.HasPrefix(tag, "'")
+
+	start := 0
+	if quoted {
+		start = 1
+	}
+
+	nameRunes := make([]rune, 0, len(runes))
+	for i := start; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\\':
+			if i+1 == len(runes) {
+				return
+			}
+			i++
+			r = runes[i]
+		case quoted && r == '\'':
+			// The closing quote must be followed by the separator or the end of the tag
+			rest = string(runes[i+1:])
+			if rest != "" && !strings.HasPrefix(rest, ",") {
+				return "", "", false
+			}
+			return string(nameRunes), rest, true
+		case !quoted && r == ',':
+			return string(nameRunes), string(runes[i:]), true
+		}
+
+		if !unicode.IsPrint(r) {
+			return
+		}
+		nameRunes = append(nameRunes, r)
+	}
+
+	if quoted {
+		// Unterminated quote
+		return
+	}
+	return string(nameRunes), "", true
+}
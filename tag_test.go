@@ -33,9 +33,171 @@ func TestParseTag(t *testing.T) {
 }
 
 func TestParseTagError(t *testing.T) {
-	_, _, err := ParseTag("&")
+	_, _, err := ParseTag("a\nb")
 	require.Error(t, err)
 	tag, isInvalid := IsInvalidTag(err)
 	require.True(t, isInvalid)
-	require.EqualValues(t, "&", tag.Tag())
+	require.EqualValues(t, "a\nb", tag.Tag())
+}
+
+func TestParseTagLabel(t *testing.T) {
+	for tag, expected := range map[string]string{
+		"Disk Size (GB)":            "Disk Size (GB)",
+		"IPv4 address,omitempty":    "IPv4 address",
+		"Größe & Gewicht":           "Größe & Gewicht",
+		"'Width, Height'":           "Width, Height",
+		"'Width, Height',omitempty": "Width, Height",
+		"'It\\'s'":                  "It's",
+		"'C:\\\\'":                  "C:\\",
+		"Width\\, Height":           "Width, Height",
+		"'-'":                       "-",
+		"''":                        "",
+	} {
+		name, _, err := ParseTag(tag)
+		require.NoError(t, err, tag)
+		require.EqualValues(t, expected, name, tag)
+	}
+}
+
+func TestParseTagLabelError(t *testing.T) {
+	for _, tag := range []string{
+		"a\nb",
+		"a\tb",
+		"'unterminated",
+		"'quoted'trailing",
+		"trailing\\",
+	} {
+		_, _, err := ParseTag(tag)
+		require.Error(t, err, tag)
+		_, isInvalid := IsInvalidTag(err)
+		require.True(t, isInvalid, tag)
+	}
+}
+
+func TestParseTagOmitEmpty(t *testing.T) {
+	name, omitEmpty, err := ParseTag("test,omitempty")
+	require.NoError(t, err)
+	require.True(t, omitEmpty)
+	require.EqualValues(t, "test", name)
+}
+
+func TestParseTagTable(t *testing.T) {
+	info, err := parseTag(",table,omitempty")
+	require.NoError(t, err)
+	require.True(t, info.table)
+	require.True(t, info.omitEmpty)
+	require.EqualValues(t, "", info.name)
+
+	_, err = parseTag("test,table=yes")
+	_, isInvalid := IsInvalidTag(err)
+	require.True(t, isInvalid)
+}
+
+func TestParseTagOrder(t *testing.T) {
+	info, err := parseTag("test,order=-2")
+	require.NoError(t, err)
+	require.True(t, info.ordered)
+	require.EqualValues(t, -2, info.order)
+
+	info, err = parseTag("test")
+	require.NoError(t, err)
+	require.False(t, info.ordered)
+
+	for _, tag := range []string{"test,order", "test,order=first"} {
+		_, err = parseTag(tag)
+		_, isInvalid := IsInvalidTag(err)
+		require.True(t, isInvalid, tag)
+	}
+}
+
+func TestParseTagFormat(t *testing.T) {
+	info, err := parseTag("Memory,format=bytes")
+	require.NoError(t, err)
+	require.EqualValues(t, "Memory", info.name)
+	require.EqualValues(t, FormatBytes, info.format)
+
+	_, err = parseTag("Memory,format=unknown")
+	_, isInvalid := IsInvalidTag(err)
+	require.True(t, isInvalid)
+}
+
+func TestParseTagTime(t *testing.T) {
+	info, err := parseTag("Created,time=relative")
+	require.NoError(t, err)
+	require.EqualValues(t, TimeRelative, info.time)
+
+	// Layouts containing commas are quoted
+	info, err = parseTag("Created,time='Jan 2, 2006',omitempty")
+	require.NoError(t, err)
+	require.EqualValues(t, "Jan 2, 2006", info.time)
+	require.True(t, info.omitEmpty)
+
+	_, err = parseTag("Created,time=")
+	_, isInvalid := IsInvalidTag(err)
+	require.True(t, isInvalid)
+
+	_, err = parseTag("Created,time='Jan 2, 2006")
+	_, isInvalid = IsInvalidTag(err)
+	require.True(t, isInvalid)
+}
+
+func TestIsUnknownTagOption(t *testing.T) {
+	err := newErrorUnknownTagOption("test,unknown", "unknown")
+	uto, isUnknown := IsUnknownTagOption(err)
+	require.NotNil(t, uto)
+	require.True(t, isUnknown)
+	require.EqualValues(t, "test,unknown", uto.Tag())
+	require.EqualValues(t, "unknown", uto.Option())
+	require.EqualValues(t, "Unknown tag option 'unknown' in tag: 'test,unknown'", err.Error())
+}
+
+func TestParseTagInfo(t *testing.T) {
+	info, err := ParseTagInfo("test,omitempty,format=bytes,time=15:04")
+	require.NoError(t, err)
+	require.EqualValues(t, "test", info.Name)
+	require.EqualValues(t, []TagOption{
+		{Key: "omitempty"},
+		{Key: "format", Value: "bytes"},
+		{Key: "time", Value: "15:04"},
+	}, info.Options)
+
+	require.True(t, info.Has("omitempty"))
+	require.False(t, info.Has("table"))
+	value, ok := info.Get("format")
+	require.True(t, ok)
+	require.EqualValues(t, "bytes", value)
+
+	info, err = ParseTagInfo("test,time='Jan 2, 2006',format=bytes")
+	require.NoError(t, err)
+	require.EqualValues(t, []TagOption{
+		{Key: "time", Value: "Jan 2, 2006"},
+		{Key: "format", Value: "bytes"},
+	}, info.Options)
+
+	info, err = ParseTagInfo("")
+	require.NoError(t, err)
+	require.EqualValues(t, "", info.Name)
+	require.Empty(t, info.Options)
+}
+
+func TestParseTagInfoError(t *testing.T) {
+	_, err := ParseTagInfo("test,unknown=1")
+	uto, isUnknown := IsUnknownTagOption(err)
+	require.True(t, isUnknown)
+	require.EqualValues(t, "unknown", uto.Option())
+
+	_, err = ParseTagInfo("test,,omitempty")
+	_, isInvalid := IsInvalidTag(err)
+	require.True(t, isInvalid)
+
+	_, err = ParseTagInfo("test,format=unknown")
+	_, isInvalid = IsInvalidTag(err)
+	require.True(t, isInvalid)
+}
+
+func TestParseTagUnknownOption(t *testing.T) {
+	name, _, err := ParseTag("test,unknown")
+	require.EqualValues(t, "test", name)
+	_, isUnknown := IsUnknownTagOption(err)
+	require.True(t, isUnknown)
 }
@@ -0,0 +1,28 @@
+package human
+
+import (
+	"io"
+	"os"
+)
+
+// isTerminal checks if the given writer is an *os.File which refers to a terminal
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok || f == nil {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// TerminalWidth returns the width of the terminal the given file refers to.
+// The flag is false if the file is not a terminal or its width cannot be determined.
+func TerminalWidth(f *os.File) (width uint, ok bool) {
+	if !isTerminal(f) {
+		return
+	}
+	return terminalWidth(f.Fd())
+}
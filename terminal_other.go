@@ -0,0 +1,9 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package human
+
+// terminalWidth is not supported on this platform
+func terminalWidth(fd uintptr) (width uint, ok bool) {
+	return
+}
@@ -0,0 +1,26 @@
+package human
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTerminal(t *testing.T) {
+	require.False(t, isTerminal(bytes.NewBufferString("")))
+	require.False(t, isTerminal((*os.File)(nil)))
+}
+
+func TestTerminalWidth(t *testing.T) {
+	f, err := ioutil.TempFile("", "go-human")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	// A regular file is not a terminal
+	_, ok := TerminalWidth(f)
+	require.False(t, ok)
+}
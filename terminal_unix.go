@@ -0,0 +1,27 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package human
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// winsize is the structure filled by the TIOCGWINSZ ioctl
+type winsize struct {
+	rows    uint16
+	columns uint16
+	xPixels uint16
+	yPixels uint16
+}
+
+// terminalWidth returns the width of the terminal referred to by the given file descriptor
+func terminalWidth(fd uintptr) (width uint, ok bool) {
+	ws := &winsize{}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(ws)))
+	if errno != 0 || ws.columns == 0 {
+		return
+	}
+	return uint(ws.columns), true
+}
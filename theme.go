@@ -0,0 +1,113 @@
+package human
+
+import (
+	"io"
+	"os"
+	"reflect"
+)
+
+// Style defines the SGR parameters of an ANSI escape sequence, which are used to color
+// text. For example, "1;34" renders bold blue text. An empty Style leaves the text unchanged.
+type Style string
+
+// Theme defines the styles that are applied to the different parts of the output
+type Theme struct {
+	// Key is applied to struct field names and map keys
+	Key Style
+	// ListSymbol is applied to list symbols
+	ListSymbol Style
+	// String is applied to strings and textual values
+	String Style
+	// Number is applied to integers, floats and complex numbers
+	Number Style
+	// Bool is applied to booleans
+	Bool Style
+	// Nil is applied to nil values
+	Nil Style
+	// Error is applied to values implementing the error interface
+	Error Style
+}
+
+// ThemeDefault defines a colorful theme which works on both, dark and light terminals
+var ThemeDefault = Theme{
+	Key:        "1;34",
+	ListSymbol: "90",
+	String:     "32",
+	Number:     "36",
+	Bool:       "33",
+	Nil:        "90",
+	Error:      "31",
+}
+
+// ThemeMonochrome defines a theme which does not use any colors, but highlights keys and errors
+var ThemeMonochrome = Theme{
+	Key:   "1",
+	Nil:   "2",
+	Error: "7",
+}
+
+// ColorMode defines when the Encoder applies its Theme
+type ColorMode int
+
+const (
+	// ColorAuto applies the theme if the output is written to a terminal and the
+	// NO_COLOR environment variable is not set
+	ColorAuto ColorMode = iota
+	// ColorAlways applies the theme regardless of the output
+	ColorAlways
+	// ColorNever never applies the theme
+	ColorNever
+)
+
+// DefaultColorMode defines the default color mode
+const DefaultColorMode = ColorAuto
+
+// colorsEnabled checks if colors shall be used given a color mode and the writer
+// the output is written to
+func colorsEnabled(colorMode ColorMode, w io.Writer) bool {
+	switch colorMode {
+	case ColorAlways:
+		return true
+	case ColorAuto:
+		return os.Getenv("NO_COLOR") == "" && isTerminal(w)
+	}
+	return false
+}
+
+// colorize wraps text in the ANSI escape sequences of the given style, if colors are enabled
+func (e *Encoder) colorize(style Style, text string) string {
+	if !e.colors || style == "" || text == "" {
+		return text
+	}
+	return "\x1b[" + string(style) + "m" + text + "\x1b[0m"
+}
+
+// valueStyle returns the style of the theme that applies to the given value
+func (e *Encoder) valueStyle(v reflect.Value) Style {
+	if !v.IsValid() {
+		return e.theme.Nil
+	}
+
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		return e.theme.Nil
+	} else if v.Type().Implements(errorType) {
+		return e.theme.Error
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return e.valueStyle(v.Elem())
+	case reflect.String:
+		return e.theme.String
+	case reflect.Bool:
+		return e.theme.Bool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return e.theme.Number
+	}
+	return ""
+}
+
+// errorType is the reflect.Type of the error interface
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
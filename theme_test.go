@@ -0,0 +1,70 @@
+package human
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestColorsEnabled(t *testing.T) {
+	buffer := bytes.NewBufferString("")
+	require.True(t, colorsEnabled(ColorAlways, buffer))
+	require.False(t, colorsEnabled(ColorNever, buffer))
+	// A buffer is not a terminal
+	require.False(t, colorsEnabled(ColorAuto, buffer))
+
+	f, err := ioutil.TempFile("", "go-human")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	defer f.Close()
+	// A regular file is not a terminal
+	require.False(t, colorsEnabled(ColorAuto, f))
+}
+
+func TestEncoder_Encode_Theme(t *testing.T) {
+	s := struct {
+		Name    string
+		Count   int
+		Enabled bool
+		Err     error
+		List    []interface{}
+	}{
+		Name:    "test",
+		Count:   1,
+		Enabled: true,
+		Err:     errors.New("failed"),
+		List:    []interface{}{nil},
+	}
+
+	t.Run("Always", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer, OptionTheme(ThemeDefault), OptionColorMode(ColorAlways))
+		require.NoError(t, err)
+
+		expectedOutput := "\n" +
+			"\x1b[1;34mName\x1b[0m: \x1b[32mtest\x1b[0m\n" +
+			"\x1b[1;34mCount\x1b[0m: \x1b[36m1\x1b[0m\n" +
+			"\x1b[1;34mEnabled\x1b[0m: \x1b[33mtrue\x1b[0m\n" +
+			"\x1b[1;34mErr\x1b[0m: \x1b[31mfailed\x1b[0m\n" +
+			"\x1b[1;34mList\x1b[0m:\n" +
+			"  \x1b[90m*\x1b[0m \x1b[90m<nil>\x1b[0m\n"
+
+		require.NoError(t, enc.Encode(s))
+		require.EqualValues(t, expectedOutput, outputBuffer.String())
+	})
+
+	t.Run("Auto", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer, OptionTheme(ThemeDefault))
+		require.NoError(t, err)
+
+		expectedOutput := "\nName: test\nCount: 1\nEnabled: true\nErr: failed\nList:\n  * <nil>\n"
+
+		require.NoError(t, enc.Encode(s))
+		require.EqualValues(t, expectedOutput, outputBuffer.String())
+	})
+}
@@ -0,0 +1,105 @@
+package human
+
+import (
+	"math"
+	"reflect"
+	"time"
+)
+
+// DefaultTimeFormat defines the default layout used for rendering time.Time values.
+// It matches the layout used by time.Time.MarshalText.
+const DefaultTimeFormat = time.RFC3339Nano
+
+// TimeRelative is the value of the "time" tag option that renders times relative to
+// the current time, like "3 hours ago". The zero time is rendered as "never".
+const TimeRelative = "relative"
+
+// timeLayouts maps the names which may be used in the "time" tag option to layouts
+var timeLayouts = map[string]string{
+	"ansic":    time.ANSIC,
+	"rfc822":   time.RFC822,
+	"rfc822z":  time.RFC822Z,
+	"rfc850":   time.RFC850,
+	"rfc1123":  time.RFC1123,
+	"rfc1123z": time.RFC1123Z,
+	"rfc3339":  time.RFC3339,
+	"kitchen":  time.Kitchen,
+	"date":     "2006-01-02",
+	"datetime": "2006-01-02 15:04:05",
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// timeValue returns the time.Time held by the given value, dereferencing pointers and interfaces.
+// The flag is false if the value does not hold a time.Time.
+func timeValue(v reflect.Value) (t time.Time, ok bool) {
+	for (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && !v.IsNil() {
+		v = v.Elem()
+	}
+	if !v.IsValid() || v.Type() != timeType {
+		return
+	}
+	return v.Interface().(time.Time), true
+}
+
+// formatTime renders a time.Time. The layout may be overridden using the name of a layout
+// defined in timeLayouts, a custom layout or TimeRelative.
+func (e *Encoder) formatTime(t time.Time, layout string) string {
+	if layout == TimeRelative || (layout == "" && e.relativeTime) {
+		return formatRelativeTime(t, e.clock())
+	}
+
+	if namedLayout, ok := timeLayouts[layout]; ok {
+		layout = namedLayout
+	} else if layout == "" {
+		layout = e.timeFormat
+	}
+
+	if e.timeLocation != nil {
+		t = t.In(e.timeLocation)
+	}
+	return t.Format(layout)
+}
+
+// relativeTimeUnits defines the units used for rendering relative times, from largest to smallest
+var relativeTimeUnits = []struct {
+	singular string
+	plural   string
+	duration time.Duration
+}{
+	{"year", "years", 365 * 24 * time.Hour},
+	{"month", "months", 30 * 24 * time.Hour},
+	{"day", "days", 24 * time.Hour},
+	{"hour", "hours", time.Hour},
+	{"minute", "minutes", time.Minute},
+	{"second", "seconds", time.Second},
+}
+
+// formatRelativeTime renders a time relative to now using its most significant unit,
+// like "3 hours ago" or "in 2 days". The zero time is rendered as "never".
+func formatRelativeTime(t, now time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		// Sub saturates, so the duration may be the minimum, which cannot be negated
+		d = -d
+		if d < 0 {
+			d = math.MaxInt64
+		}
+	}
+
+	for _, unit := range relativeTimeUnits {
+		if count := int(d / unit.duration); count > 0 {
+			text := pluralize(count, unit.singular, unit.plural)
+			if future {
+				return "in " + text
+			}
+			return text + " ago"
+		}
+	}
+	return "just now"
+}
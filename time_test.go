@@ -0,0 +1,104 @@
+package human
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatRelativeTime(t *testing.T) {
+	now := time.Date(2017, 10, 9, 12, 0, 0, 0, time.UTC)
+
+	require.EqualValues(t, "just now", formatRelativeTime(now, now))
+	require.EqualValues(t, "1 second ago", formatRelativeTime(now.Add(-time.Second), now))
+	require.EqualValues(t, "3 hours ago", formatRelativeTime(now.Add(-3*time.Hour-5*time.Minute), now))
+	require.EqualValues(t, "in 2 days", formatRelativeTime(now.Add(50*time.Hour), now))
+	require.EqualValues(t, "in 1 month", formatRelativeTime(now.AddDate(0, 1, 0), now))
+	require.EqualValues(t, "2 years ago", formatRelativeTime(now.AddDate(-2, 0, 0), now))
+
+	// The zero time has no meaningful distance, far away times saturate
+	require.EqualValues(t, "never", formatRelativeTime(time.Time{}, now))
+	require.EqualValues(t, "in 292 years", formatRelativeTime(now.AddDate(1000, 0, 0), now))
+	require.EqualValues(t, "292 years ago", formatRelativeTime(now.AddDate(-1000, 0, 0), now))
+}
+
+func TestEncoder_Encode_Time(t *testing.T) {
+	now := time.Date(2017, 10, 9, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time {
+		return now
+	}
+
+	created := now.Add(-3 * time.Hour)
+	s := struct {
+		Created  time.Time
+		Updated  *time.Time
+		Expires  time.Time   `human:",time=relative"`
+		Date     time.Time   `human:",time=date"`
+		Schedule []time.Time `human:",time=15:04"`
+	}{
+		Created:  created,
+		Updated:  &created,
+		Expires:  now.Add(50 * time.Hour),
+		Date:     now,
+		Schedule: []time.Time{now, now.Add(90 * time.Minute)},
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer, OptionClock(clock))
+		require.NoError(t, err)
+
+		expectedOutput := "\n" +
+			"Created: 2017-10-09T09:00:00Z\n" +
+			"Updated: 2017-10-09T09:00:00Z\n" +
+			"Expires: in 2 days\n" +
+			"Date: 2017-10-09\n" +
+			"Schedule:\n" +
+			"  * 12:00\n" +
+			"  * 13:30\n"
+
+		require.NoError(t, enc.Encode(s))
+		require.EqualValues(t, expectedOutput, outputBuffer.String())
+	})
+
+	t.Run("FormatAndLocation", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer,
+			OptionTimeFormat("2006-01-02 15:04 MST"),
+			OptionTimeLocation(time.FixedZone("CET", 3600)),
+			OptionClock(clock))
+		require.NoError(t, err)
+
+		expectedOutput := "\n" +
+			"Created: 2017-10-09 10:00 CET\n" +
+			"Updated: 2017-10-09 10:00 CET\n" +
+			"Expires: in 2 days\n" +
+			"Date: 2017-10-09\n" +
+			"Schedule:\n" +
+			"  * 13:00\n" +
+			"  * 14:30\n"
+
+		require.NoError(t, enc.Encode(s))
+		require.EqualValues(t, expectedOutput, outputBuffer.String())
+	})
+
+	t.Run("Relative", func(t *testing.T) {
+		outputBuffer := bytes.NewBufferString("")
+		enc, err := NewEncoder(outputBuffer, OptionRelativeTime(true), OptionClock(clock))
+		require.NoError(t, err)
+
+		expectedOutput := "\n" +
+			"Created: 3 hours ago\n" +
+			"Updated: 3 hours ago\n" +
+			"Expires: in 2 days\n" +
+			"Date: 2017-10-09\n" +
+			"Schedule:\n" +
+			"  * 12:00\n" +
+			"  * 13:30\n"
+
+		require.NoError(t, enc.Encode(s))
+		require.EqualValues(t, expectedOutput, outputBuffer.String())
+	})
+}
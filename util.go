@@ -1,6 +1,10 @@
 package human
 
-import "reflect"
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
 
 // IsNilOrEmpty checks if a passed interface is either nil or of
 // the type's zero value.
@@ -15,3 +19,24 @@ func IsNilOrEmpty(i interface{}, v reflect.Value) bool {
 	// Hard case: check if interface has "zero" value (ie. empty string, zero integer, etc.)
 	return reflect.DeepEqual(i, reflect.Zero(v.Type()).Interface())
 }
+
+// formatPath joins the elements of a field path. Elements starting with "[" denote
+// slice indices or map keys and are appended without separator.
+func formatPath(path []string) string {
+	var formatted []string
+	for i, element := range path {
+		if i > 0 && !strings.HasPrefix(element, "[") {
+			formatted = append(formatted, ".")
+		}
+		formatted = append(formatted, element)
+	}
+	return strings.Join(formatted, "")
+}
+
+// pluralize returns the count followed by the singular or plural noun
+func pluralize(count int, singular, plural string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, singular)
+	}
+	return fmt.Sprintf("%d %s", count, plural)
+}
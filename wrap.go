@@ -0,0 +1,92 @@
+package human
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// minWrapWidth defines the minimum width text is wrapped at, regardless of the
+// remaining width of the line
+const minWrapWidth = 10
+
+// displayWidth returns the number of runes of a text, ignoring ANSI escape sequences
+func displayWidth(text string) (width int) {
+	inEscape := false
+	for _, r := range text {
+		switch {
+		case inEscape:
+			inEscape = r != 'm'
+		case r == '\x1b':
+			inEscape = true
+		default:
+			width++
+		}
+	}
+	return
+}
+
+// wrapText splits a text into lines which do not exceed the given width.
+// Lines are split at spaces, words which exceed the width on their own are split
+// at the width.
+func wrapText(text string, width int) (lines []string) {
+	if utf8.RuneCountInString(text) <= width {
+		return []string{text}
+	}
+
+	line := ""
+	for _, word := range strings.Fields(text) {
+		// Split words which do not fit into a line on their own
+		for utf8.RuneCountInString(word) > width {
+			if line != "" {
+				lines = append(lines, line)
+				line = ""
+			}
+			runes := []rune(word)
+			lines = append(lines, string(runes[:width]))
+			word = string(runes[width:])
+		}
+
+		if line == "" {
+			line = word
+		} else if utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) <= width {
+			line += " " + word
+		} else {
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" || len(lines) == 0 {
+		lines = append(lines, line)
+	}
+	return
+}
+
+// currentColumn returns the column of the current line the next write will start at
+func (e *encodeState) currentColumn() int {
+	buffered := e.stream.Bytes()
+	return displayWidth(string(buffered[bytes.LastIndexByte(buffered, '\n')+1:]))
+}
+
+// writeWrapped writes a prefix followed by a line of text, which is colored using the given style.
+// If a width is configured, the text is soft-wrapped and continuation lines are aligned with
+// the start of the text.
+func (e *encodeState) writeWrapped(prefix, text string, style Style) {
+	if e.width == 0 {
+		fmt.Fprintln(e.stream, prefix+e.colorize(style, text))
+		return
+	}
+
+	start := e.currentColumn() + displayWidth(prefix)
+	width := int(e.width) - start
+	if width < minWrapWidth {
+		width = minWrapWidth
+	}
+
+	lines := wrapText(text, width)
+	fmt.Fprintln(e.stream, prefix+e.colorize(style, lines[0]))
+	for _, line := range lines[1:] {
+		fmt.Fprintln(e.stream, strings.Repeat(" ", start)+e.colorize(style, line))
+	}
+}
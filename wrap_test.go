@@ -0,0 +1,48 @@
+package human
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	require.EqualValues(t, 4, displayWidth("test"))
+	require.EqualValues(t, 4, displayWidth("\x1b[1;34mtest\x1b[0m"))
+	require.EqualValues(t, 3, displayWidth("äöü"))
+}
+
+func TestWrapText(t *testing.T) {
+	require.EqualValues(t, []string{"short"}, wrapText("short", 10))
+	require.EqualValues(t, []string{""}, wrapText("", 10))
+	require.EqualValues(t, []string{"the quick", "brown fox", "jumps"}, wrapText("the quick brown fox jumps", 10))
+	require.EqualValues(t, []string{"a", "0123456789", "0123 b"}, wrapText("a 01234567890123 b", 10))
+}
+
+func TestEncoder_Encode_Width(t *testing.T) {
+	outputBuffer := bytes.NewBufferString("")
+	enc, err := NewEncoder(outputBuffer, OptionWidth(30))
+	require.NoError(t, err)
+
+	s := struct {
+		Description string
+		Notes       []string
+	}{
+		Description: "the quick brown fox jumps over the lazy dog",
+		Notes:       []string{"first line\nthe quick brown fox jumps over the lazy dog"},
+	}
+
+	expectedOutput := "\n" +
+		"Description: the quick brown\n" +
+		"             fox jumps over\n" +
+		"             the lazy dog\n" +
+		"Notes:\n" +
+		"  * |\n" +
+		"    first line\n" +
+		"    the quick brown fox jumps\n" +
+		"    over the lazy dog\n"
+
+	require.NoError(t, enc.Encode(s))
+	require.EqualValues(t, expectedOutput, outputBuffer.String())
+}